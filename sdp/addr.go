@@ -0,0 +1,128 @@
+package sdp
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// NewConnection returns internet connection data for the IP address.
+// The address type is set to IP4 for IPv4 and IPv4-mapped IPv6 addresses and to IP6 otherwise.
+func NewConnection(addr netip.Addr) *Connection {
+	addr = addr.Unmap().WithZone("")
+	return &Connection{
+		Network: NetworkInternet,
+		Type:    addrType(addr),
+		Address: addr.String(),
+	}
+}
+
+// NewOriginAddr returns an origin with a random session ID for the IP address.
+// The address type is set as in NewConnection.
+func NewOriginAddr(username string, addr netip.Addr) *Origin {
+	addr = addr.Unmap().WithZone("")
+	o := NewOrigin(username, addr.String())
+	o.Type = addrType(addr)
+	return o
+}
+
+// Addr returns the connection IP address.
+// It returns the zero Addr if the address is a fully qualified domain name.
+func (c *Connection) Addr() netip.Addr {
+	return parseAddr(c.Address)
+}
+
+// Hostname returns the connection address if it is a fully qualified domain name.
+func (c *Connection) Hostname() string {
+	return hostname(c.Address)
+}
+
+// Addr returns the origin IP address.
+// It returns the zero Addr if the address is a fully qualified domain name.
+func (o *Origin) Addr() netip.Addr {
+	return parseAddr(o.Address)
+}
+
+// Hostname returns the origin address if it is a fully qualified domain name.
+func (o *Origin) Hostname() string {
+	return hostname(o.Address)
+}
+
+// RTPAddr returns the RTP transport address of the media from its first connection data and port.
+// It returns the zero AddrPort if the media has no connection data or the address is not an IP address.
+// See Session.RTPAddr for connection data inherited from the session.
+func (m *Media) RTPAddr() netip.AddrPort {
+	if len(m.Connection) == 0 {
+		return netip.AddrPort{}
+	}
+	return rtpAddr(m.Connection[0], m.Port)
+}
+
+// RTPAddr returns the RTP transport address of the media using session-level connection data
+// if the media has none.
+func (s *Session) RTPAddr(m *Media) netip.AddrPort {
+	return rtpAddr(s.EffectiveConnection(m), m.Port)
+}
+
+func rtpAddr(c *Connection, port int) netip.AddrPort {
+	if c == nil || port < 0 || port > 0xffff {
+		return netip.AddrPort{}
+	}
+	addr := c.Addr()
+	if !addr.IsValid() {
+		return netip.AddrPort{}
+	}
+	return netip.AddrPortFrom(addr, uint16(port))
+}
+
+func parseAddr(v string) netip.Addr {
+	if !isAddrLiteral(v) {
+		return netip.Addr{}
+	}
+	addr, err := netip.ParseAddr(v)
+	if err != nil || addr.Zone() != "" {
+		return netip.Addr{}
+	}
+	return addr
+}
+
+// isAddrLiteral reports whether v may be an IP address literal to avoid parsing hostnames.
+func isAddrLiteral(v string) bool {
+	if strings.IndexByte(v, ':') >= 0 {
+		return true
+	}
+	for i := 0; i < len(v); i++ {
+		if ch := v[i]; ch != '.' && (ch < '0' || ch > '9') {
+			return false
+		}
+	}
+	return v != ""
+}
+
+func hostname(v string) string {
+	if v == "" || parseAddr(v).IsValid() {
+		return ""
+	}
+	return v
+}
+
+func addrType(addr netip.Addr) string {
+	if addr.Is4() {
+		return TypeIPv4
+	}
+	return TypeIPv6
+}
+
+// checkAddr reports an error if the IP address literal does not match the internet address type.
+func checkAddr(network, typ, address string) error {
+	if network != NetworkInternet {
+		return nil
+	}
+	addr := parseAddr(address)
+	switch {
+	case !addr.IsValid():
+		return nil
+	case typ == TypeIPv4 && !addr.Is4(), typ == TypeIPv6 && addr.Is4():
+		return errAddrType
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package sdp
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestAddr(t *testing.T) {
+	c := NewConnection(netip.MustParseAddr("::ffff:192.0.2.1"))
+	if c.Type != TypeIPv4 || c.Address != "192.0.2.1" || c.Addr() != netip.MustParseAddr("192.0.2.1") {
+		t.Errorf("bad connection: %+v", c)
+	}
+	if c = NewConnection(netip.MustParseAddr("fe80::1%eth0")); c.Type != TypeIPv6 || c.Address != "fe80::1" {
+		t.Errorf("bad connection: %+v", c)
+	}
+	o := NewOriginAddr("alice", netip.MustParseAddr("2001:db8::1"))
+	if o.Type != TypeIPv6 || o.Addr() != netip.MustParseAddr("2001:db8::1") || o.Hostname() != "" {
+		t.Errorf("bad origin: %+v", o)
+	}
+	o.Address = "host.example.com"
+	if o.Addr().IsValid() || o.Hostname() != "host.example.com" {
+		t.Errorf("bad origin hostname: %+v", o)
+	}
+	s, err := ParseString("v=0\r\no=- 1 1 IN IP4 192.0.2.1\r\ns=-\r\nc=IN IP4 224.2.1.1/127\r\nt=0 0\r\nm=audio 49170 RTP/AVP 0\r\nm=video 51372 RTP/AVP 99\r\nc=IN IP6 2001:db8::2\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := s.Media[0].RTPAddr(); v.IsValid() {
+		t.Errorf("unexpected media address: %v", v)
+	}
+	if v := s.RTPAddr(s.Media[0]); v != netip.MustParseAddrPort("224.2.1.1:49170") {
+		t.Errorf("bad session address: %v", v)
+	}
+	if v := s.Media[1].RTPAddr(); v != netip.MustParseAddrPort("[2001:db8::2]:51372") {
+		t.Errorf("bad media address: %v", v)
+	}
+	for _, it := range []string{
+		"v=0\r\no=- 1 1 IN IP6 192.0.2.1\r\ns=-\r\nt=0 0\r\n",
+		"v=0\r\no=- 1 1 IN IP4 2001:db8::1\r\ns=-\r\nt=0 0\r\n",
+		"v=0\r\no=- 1 1 IN IP4 192.0.2.1\r\ns=-\r\nc=IN IP4 ff15::101/3\r\nt=0 0\r\n",
+		"v=0\r\no=- 1 1 IN IP4 192.0.2.1\r\ns=-\r\nc=IN IP6 224.2.1.1/3\r\nt=0 0\r\n",
+	} {
+		if _, err := ParseString(it); err == nil {
+			t.Errorf("expected address type error: %q", it)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package sdp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Anonymize returns a copy of the session suitable for logging with personal and secret values
+// replaced by deterministic pseudonyms: origin username and address, email addresses and phone numbers,
+// connection, "a=rtcp" and "a=candidate" addresses, ICE passwords, "a=crypto" inline keys,
+// "a=fingerprint" hashes, "k=" keys and "a=ssrc" CNAMEs. Pseudonyms keep the syntax of original values,
+// equal values get equal pseudonyms, so the result remains parseable and comparable.
+// Unspecified, loopback and multicast addresses are kept.
+// Pseudonyms are derived with HMAC-SHA256 using the key, which should be secret to prevent
+// recovering values by brute force.
+func (s *Session) Anonymize(key []byte) *Session {
+	a := &anonymizer{key: key}
+	c := s.Clone()
+	if o := c.Origin; o != nil {
+		if o.Username != "-" {
+			o.Username = a.token("user", o.Username)
+		}
+		o.Address = a.addr(o.Address)
+	}
+	for i, v := range c.Email {
+		c.Email[i] = a.token("user", v) + "@example.invalid"
+	}
+	for i, v := range c.Phone {
+		c.Phone[i] = a.phone(v)
+	}
+	a.connection(c.Connection)
+	a.keys(c.Key)
+	a.attrs(c.Attributes)
+	for _, m := range c.Media {
+		for _, it := range m.Connection {
+			a.connection(it)
+		}
+		a.keys(m.Key)
+		a.attrs(m.Attributes)
+	}
+	return c
+}
+
+type anonymizer struct {
+	key []byte
+}
+
+func (a *anonymizer) sum(v string) []byte {
+	h := hmac.New(sha256.New, a.key)
+	h.Write([]byte(v))
+	return h.Sum(nil)
+}
+
+// token returns a pseudonym with the prefix.
+func (a *anonymizer) token(prefix, v string) string {
+	return prefix + "-" + hex.EncodeToString(a.sum(v)[:4])
+}
+
+// secret returns a base64 pseudonym of the same length.
+func (a *anonymizer) secret(v string) string {
+	b := base64.RawStdEncoding.EncodeToString(a.sum(v))
+	for len(b) < len(v) {
+		b += b
+	}
+	return b[:len(v)]
+}
+
+func (a *anonymizer) phone(v string) string {
+	b := a.sum(v)
+	r := []byte("+")
+	for _, it := range b[:5] {
+		r = strconv.AppendInt(r, int64(it%100/10), 10)
+		r = strconv.AppendInt(r, int64(it%10), 10)
+	}
+	return string(r)
+}
+
+// addr returns a pseudonym address of the same type: a private IPv4 or unique local IPv6 address,
+// or a hostname in the same top-level domain for mDNS ".local" names and in ".invalid" otherwise.
+func (a *anonymizer) addr(v string) string {
+	addr := parseAddr(v)
+	if !addr.IsValid() {
+		if v == "" || v == "-" {
+			return v
+		}
+		if strings.HasSuffix(strings.ToLower(v), ".local") {
+			return a.token("host", v) + ".local"
+		}
+		return a.token("host", v) + ".invalid"
+	}
+	if addr.IsUnspecified() || addr.IsLoopback() || addr.IsMulticast() {
+		return v
+	}
+	b := a.sum(v)
+	if addr.Is4() {
+		return netip.AddrFrom4([4]byte{10, b[0], b[1], b[2]}).String()
+	}
+	var ip [16]byte
+	ip[0] = 0xfd
+	copy(ip[1:], b)
+	return netip.AddrFrom16(ip).String()
+}
+
+func (a *anonymizer) connection(c *Connection) {
+	if c != nil {
+		c.Address = a.addr(c.Address)
+	}
+}
+
+func (a *anonymizer) keys(keys []*Key) {
+	for _, k := range keys {
+		if k.Value != "" {
+			k.Value = a.secret(k.Value)
+		}
+	}
+}
+
+func (a *anonymizer) attrs(attrs Attributes) {
+	for _, it := range attrs {
+		switch it.Name {
+		case "ice-pwd":
+			it.Value = a.secret(it.Value)
+		case "candidate":
+			p := strings.Fields(it.Value)
+			if len(p) > 4 {
+				p[4] = a.addr(p[4])
+			}
+			for i := 6; i+1 < len(p); i++ {
+				if p[i] == "raddr" {
+					p[i+1] = a.addr(p[i+1])
+				}
+			}
+			it.Value = strings.Join(p, " ")
+		case "rtcp":
+			if p := strings.Fields(it.Value); len(p) == 4 {
+				p[3] = a.addr(p[3])
+				it.Value = strings.Join(p, " ")
+			}
+		case "crypto":
+			it.Value = a.crypto(it.Value)
+		case "fingerprint":
+			if p := strings.Fields(it.Value); len(p) == 2 {
+				b := a.sum(p[1])
+				h := make([]string, (len(p[1])+1)/3)
+				for i := range h {
+					h[i] = strings.ToUpper(hex.EncodeToString(b[i%len(b) : i%len(b)+1]))
+				}
+				it.Value = p[0] + " " + strings.Join(h, ":")
+			}
+		case "ssrc":
+			if name, v := ssrcAttr(it.Value); name == "cname" {
+				it.Value = ssrcID(it.Value) + " cname:" + a.token("cname", v)
+			}
+		}
+	}
+}
+
+// crypto replaces inline key-salt values of the "a=crypto" attribute as described in RFC 4568.
+func (a *anonymizer) crypto(v string) string {
+	p := strings.Fields(v)
+	if len(p) < 3 {
+		return v
+	}
+	keys := strings.Split(p[2], ";")
+	for i, k := range keys {
+		if !strings.HasPrefix(k, "inline:") {
+			continue
+		}
+		k = k[len("inline:"):]
+		j := strings.IndexByte(k, '|')
+		if j < 0 {
+			j = len(k)
+		}
+		keys[i] = "inline:" + a.secret(k[:j]) + k[j:]
+	}
+	p[2] = strings.Join(keys, ";")
+	return strings.Join(p, " ")
+}
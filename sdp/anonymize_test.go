@@ -0,0 +1,40 @@
+package sdp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnonymize(t *testing.T) {
+	s, err := ParseString("v=0\r\no=alice 1 1 IN IP4 198.51.100.7\r\ns=-\r\ne=alice@example.com\r\np=+1 617 555-6011\r\nc=IN IP4 198.51.100.7\r\nt=0 0\r\n" +
+		"a=ice-ufrag:F7gI\r\na=ice-pwd:x9cml/YzichV2+XlhiMu8g\r\na=fingerprint:sha-256 49:66:12:17:0D:1C:91:AE:57:4C:C6:36:DD:D5:97:D2:7D:62:C9:9A:7F:B9:A3:45:4B:A6:C3:2A:2D:14:AB:2F\r\n" +
+		"m=audio 5004 RTP/SAVP 0\r\nk=base64:c2VjcmV0\r\na=rtcp:5005 IN IP4 198.51.100.7\r\n" +
+		"a=crypto:1 AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR|2^20|1:32\r\n" +
+		"a=candidate:1 1 UDP 1694498815 203.0.113.9 5004 typ srflx raddr 198.51.100.7 rport 5004\r\n" +
+		"a=candidate:2 1 UDP 2130706431 4e6a.local 5004 typ host\r\na=ssrc:1 cname:alice@example.com\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := s.Anonymize([]byte("secret")).String()
+	for _, it := range []string{"alice", "198.51.100.7", "203.0.113.9", "617", "x9cml", "c2VjcmV0", "PS1uQCVe", "49:66:12", "4e6a"} {
+		if strings.Contains(v, it) {
+			t.Errorf("anonymized session contains %q:\n%s", it, v)
+		}
+	}
+	a, err := ParseString(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Origin.Address != a.Connection.Address || len(a.Attributes.Get("ice-pwd")) != 22 {
+		t.Errorf("bad pseudonyms:\n%s", v)
+	}
+	if !strings.Contains(v, "|2^20|1:32") || !strings.Contains(v, ".local 5004 typ host") || !strings.Contains(v, "a=ice-ufrag:F7gI") {
+		t.Errorf("bad anonymized session:\n%s", v)
+	}
+	if w := s.Anonymize([]byte("secret")).String(); w != v {
+		t.Errorf("pseudonyms are not deterministic")
+	}
+	if w := s.Anonymize([]byte("other")).String(); w == v {
+		t.Errorf("pseudonyms do not depend on key")
+	}
+}
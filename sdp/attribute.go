@@ -25,7 +25,8 @@ func (a Attributes) Get(name string) string {
 
 // Attr represents session or media attribute.
 type Attr struct {
-	Name, Value string
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
 }
 
 // NewAttr returns a=<attribute>:<value> attribute.
@@ -0,0 +1,130 @@
+package sdp
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bandwidth types.
+const (
+	BandwidthCT   = "CT"   // Conference Total in kbit/s (RFC 4566)
+	BandwidthAS   = "AS"   // Application Specific maximum in kbit/s including IP overhead (RFC 4566)
+	BandwidthTIAS = "TIAS" // Transport Independent Application Specific maximum in bit/s (RFC 3890)
+	BandwidthRS   = "RS"   // RTCP bandwidth allocated to active data senders in bit/s (RFC 3556)
+	BandwidthRR   = "RR"   // RTCP bandwidth allocated to other participants in bit/s (RFC 3556)
+)
+
+// Per-packet IP, UDP and RTP header overhead in bytes.
+const (
+	OverheadIPv4 = 20 + 8 + 12
+	OverheadIPv6 = 40 + 8 + 12
+)
+
+// DefaultPacketTime is the packet time assumed when the media has no "ptime" attribute.
+const DefaultPacketTime = 20 * time.Millisecond
+
+// Bitrate returns the bandwidth value in bits per second.
+// It returns zero for unknown bandwidth types.
+func (b *Bandwidth) Bitrate() int {
+	switch strings.ToUpper(b.Type) {
+	case BandwidthCT, BandwidthAS:
+		return b.Value * 1000
+	case BandwidthTIAS, BandwidthRS, BandwidthRR:
+		return b.Value
+	}
+	return 0
+}
+
+// ASFromTIAS converts TIAS bandwidth in bit/s to AS bandwidth in kbit/s
+// adding overhead bytes for each packet sent every ptime as described in RFC 3890 section 6.4.
+func ASFromTIAS(tias int, ptime time.Duration, overhead int) int {
+	bps := int64(tias) + packetOverhead(ptime, overhead)
+	return int((bps + 999) / 1000)
+}
+
+// TIASFromAS converts AS bandwidth in kbit/s to TIAS bandwidth in bit/s
+// subtracting overhead bytes for each packet sent every ptime.
+func TIASFromAS(as int, ptime time.Duration, overhead int) int {
+	bps := int64(as)*1000 - packetOverhead(ptime, overhead)
+	if bps < 0 {
+		return 0
+	}
+	return int(bps)
+}
+
+func packetOverhead(ptime time.Duration, overhead int) int64 {
+	if ptime <= 0 {
+		ptime = DefaultPacketTime
+	}
+	return int64(overhead) * 8 * int64(time.Second) / int64(ptime)
+}
+
+// PacketTime returns the media packet time from the "ptime" attribute or DefaultPacketTime.
+func (m *Media) PacketTime() time.Duration {
+	if v := m.Attributes.Get("ptime"); v != "" {
+		if ms, err := strconv.ParseFloat(v, 64); err == nil && ms > 0 {
+			return time.Duration(ms * float64(time.Millisecond))
+		}
+	}
+	return DefaultPacketTime
+}
+
+// MediaBitrate returns the effective maximum bitrate of the media in bits per second without IP overhead.
+// Media-level TIAS and AS values take precedence over session-level ones, AS values are converted
+// using the media packet time and IPv4 overhead. The result is limited by session CT value, if any.
+// It returns zero if no bandwidth is specified.
+func (s *Session) MediaBitrate(m *Media) int {
+	bps := mediaBitrate(m.Bandwidth, m.PacketTime())
+	if bps == 0 {
+		bps = mediaBitrate(s.Bandwidth, m.PacketTime())
+	}
+	if ct, ok := bandwidthValue(s.Bandwidth, BandwidthCT); ok && (bps == 0 || ct*1000 < bps) {
+		bps = ct * 1000
+	}
+	return bps
+}
+
+func mediaBitrate(b []*Bandwidth, ptime time.Duration) int {
+	if v, ok := bandwidthValue(b, BandwidthTIAS); ok {
+		return v
+	}
+	if v, ok := bandwidthValue(b, BandwidthAS); ok {
+		return TIASFromAS(v, ptime, OverheadIPv4)
+	}
+	return 0
+}
+
+// RTCPBitrate returns RTCP bandwidth of the media in bits per second allocated to senders
+// and other participants. Media-level RS and RR values take precedence over session-level ones,
+// otherwise they default to 1.25% and 3.75% of AS bandwidth as described in RFC 3556.
+func (s *Session) RTCPBitrate(m *Media) (senders, receivers int) {
+	as, ok := bandwidthValue(m.Bandwidth, BandwidthAS)
+	if !ok {
+		as, _ = bandwidthValue(s.Bandwidth, BandwidthAS)
+	}
+	senders, receivers = as*1000/80, as*1000*3/80
+	if v, ok := rtcpBandwidth(s, m, BandwidthRS); ok {
+		senders = v
+	}
+	if v, ok := rtcpBandwidth(s, m, BandwidthRR); ok {
+		receivers = v
+	}
+	return
+}
+
+func rtcpBandwidth(s *Session, m *Media, typ string) (int, bool) {
+	if v, ok := bandwidthValue(m.Bandwidth, typ); ok {
+		return v, true
+	}
+	return bandwidthValue(s.Bandwidth, typ)
+}
+
+func bandwidthValue(b []*Bandwidth, typ string) (int, bool) {
+	for _, it := range b {
+		if strings.EqualFold(it.Type, typ) {
+			return it.Value, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,31 @@
+package sdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBandwidth(t *testing.T) {
+	if v := ASFromTIAS(64000, 20*time.Millisecond, OverheadIPv4); v != 80 {
+		t.Errorf("bad AS: %d", v)
+	}
+	if v := TIASFromAS(80, 20*time.Millisecond, OverheadIPv4); v != 64000 {
+		t.Errorf("bad TIAS: %d", v)
+	}
+	s := &Session{
+		Bandwidth: []*Bandwidth{{BandwidthCT, 1000}, {BandwidthAS, 800}, {BandwidthRR, 0}},
+		Media: []*Media{
+			{Type: "audio", Bandwidth: []*Bandwidth{{BandwidthTIAS, 64000}}},
+			{Type: "video", Attributes: Attributes{NewAttr("ptime", "40")}},
+			{Type: "video", Bandwidth: []*Bandwidth{{BandwidthAS, 2000}}},
+		},
+	}
+	for i, exp := range []int{64000, 792000, 1000000} {
+		if v := s.MediaBitrate(s.Media[i]); v != exp {
+			t.Errorf("bad bitrate of media %d: %d, expected %d", i, v, exp)
+		}
+	}
+	if rs, rr := s.RTCPBitrate(s.Media[2]); rs != 25000 || rr != 0 {
+		t.Errorf("bad rtcp bitrate: %d %d", rs, rr)
+	}
+}
@@ -0,0 +1,259 @@
+package sdp
+
+import (
+	"errors"
+	"strconv"
+)
+
+// EncodeError reports an invalid session description field.
+type EncodeError struct {
+	Field string // Field name, for example "s=" or "a=fmtp"
+	Media int    // Media description index or -1 for session-level fields
+	Err   error
+}
+
+func (e *EncodeError) Error() string {
+	s := "sdp: " + e.Err.Error() + " in " + e.Field
+	if e.Media >= 0 {
+		s += " of media " + strconv.Itoa(e.Media)
+	}
+	return s
+}
+
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	errMissing = errors.New("missing value")
+	errChar    = errors.New("invalid character")
+	errValue   = errors.New("invalid value")
+)
+
+// checker validates session description fields against RFC 4566 grammar before encoding
+// to prevent injection of extra lines. In strict mode, it also reports missing mandatory values
+// which are otherwise replaced with defaults by the encoder.
+type checker struct {
+	strict bool
+	media  int
+}
+
+func (c *checker) err(field string, err error) error {
+	return &EncodeError{Field: field, Media: c.media, Err: err}
+}
+
+// check validates characters of the value. Empty value is reported in strict mode if it is required.
+func (c *checker) check(field, v string, valid func(ch byte) bool, required bool) error {
+	if v == "" {
+		if required && c.strict {
+			return c.err(field, errMissing)
+		}
+		return nil
+	}
+	if !validString(v, valid) {
+		return c.err(field, errChar)
+	}
+	return nil
+}
+
+func validString(v string, valid func(ch byte) bool) bool {
+	for i := 0; i < len(v); i++ {
+		if !valid(v[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *checker) session(s *Session) error {
+	c.media = -1
+	if o := s.Origin; o != nil {
+		if err := c.check("o= username", o.Username, isVisible, true); err != nil {
+			return err
+		}
+		if err := c.transport("o=", o.Network, o.Type, o.Address); err != nil {
+			return err
+		}
+	} else if c.strict {
+		return c.err("o=", errMissing)
+	}
+	if err := c.check("s=", s.Name, isText, true); err != nil {
+		return err
+	}
+	if err := c.check("i=", s.Information, isText, false); err != nil {
+		return err
+	}
+	if err := c.check("u=", s.URI, isText, false); err != nil {
+		return err
+	}
+	for _, it := range s.Email {
+		if err := c.check("e=", it, isText, true); err != nil {
+			return err
+		}
+	}
+	for _, it := range s.Phone {
+		if err := c.check("p=", it, isText, true); err != nil {
+			return err
+		}
+	}
+	if s.Connection != nil {
+		if err := c.connection(s.Connection); err != nil {
+			return err
+		}
+	}
+	if err := c.common(s.Bandwidth, s.Key, s.Mode, s.Attributes); err != nil {
+		return err
+	}
+	for i, m := range s.Media {
+		c.media = i
+		if s.Connection == nil && len(m.Connection) == 0 && c.strict {
+			return c.err("c=", errMissing)
+		}
+		if err := c.mediaDescr(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) fragment(f *Fragment) error {
+	c.media = -1
+	if err := c.common(nil, nil, "", f.Attributes); err != nil {
+		return err
+	}
+	for i, m := range f.Media {
+		c.media = i
+		if err := c.mediaDescr(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) mediaDescr(m *Media) error {
+	if err := c.check("m= media", m.Type, isToken, true); err != nil {
+		return err
+	}
+	if m.Port < 0 || m.Port > 0xffff || m.PortNum < 0 {
+		return c.err("m= port", errValue)
+	}
+	if err := c.check("m= proto", m.Proto, isProto, true); err != nil {
+		return err
+	}
+	if m.FormatDescr == "" && len(m.Format) == 0 && c.strict {
+		return c.err("m= fmt", errMissing)
+	}
+	if err := c.check("m= fmt", m.FormatDescr, isText, false); err != nil {
+		return err
+	}
+	if err := c.check("i=", m.Information, isText, false); err != nil {
+		return err
+	}
+	for _, it := range m.Connection {
+		if err := c.connection(it); err != nil {
+			return err
+		}
+	}
+	for _, f := range m.Format {
+		if err := c.check("a=rtpmap", f.Name, isToken, false); err != nil {
+			return err
+		}
+		for _, it := range f.Feedback {
+			if err := c.check("a=rtcp-fb", it, isText, true); err != nil {
+				return err
+			}
+		}
+		for _, it := range f.Params {
+			if err := c.check("a=fmtp", it, isText, true); err != nil {
+				return err
+			}
+		}
+	}
+	return c.common(m.Bandwidth, m.Key, m.Mode, m.Attributes)
+}
+
+func (c *checker) connection(conn *Connection) error {
+	return c.transport("c=", conn.Network, conn.Type, conn.Address)
+}
+
+func (c *checker) transport(field, network, typ, addr string) error {
+	switch {
+	case c.strict && (network == "" || typ == "" || addr == ""):
+		return c.err(field+" address", errMissing)
+	case !validString(network, isToken):
+		return c.err(field+" nettype", errChar)
+	case !validString(typ, isToken):
+		return c.err(field+" addrtype", errChar)
+	case !validString(addr, isAddress):
+		return c.err(field+" address", errChar)
+	}
+	return nil
+}
+
+func (c *checker) common(bandwidth []*Bandwidth, key []*Key, mode string, attrs Attributes) error {
+	for _, b := range bandwidth {
+		if err := c.check("b= bwtype", b.Type, isToken, true); err != nil {
+			return err
+		}
+	}
+	for _, k := range key {
+		if err := c.check("k= method", k.Method, isToken, true); err != nil {
+			return err
+		}
+		if err := c.check("k=", k.Value, isText, false); err != nil {
+			return err
+		}
+	}
+	switch mode {
+	case "", SendRecv, SendOnly, RecvOnly, Inactive:
+	default:
+		return c.err("a= mode", errValue)
+	}
+	for _, a := range attrs {
+		if err := c.check("a= name", a.Name, isAttrName, true); err != nil {
+			return err
+		}
+		if !validString(a.Value, isText) {
+			return c.err("a="+a.Name, errChar)
+		}
+	}
+	return nil
+}
+
+// isText reports whether ch is allowed in byte-string: any byte except NUL, CR and LF.
+func isText(ch byte) bool {
+	return ch != 0 && ch != '\r' && ch != '\n'
+}
+
+// isVisible reports whether ch is allowed in non-ws-string: visible ASCII characters and non-ASCII bytes.
+func isVisible(ch byte) bool {
+	return ch > 0x20 && ch != 0x7f
+}
+
+// isToken reports whether ch is a token-char.
+func isToken(ch byte) bool {
+	if ch < 0x21 || ch > 0x7e {
+		return false
+	}
+	switch ch {
+	case '"', '(', ')', ',', '/', ':', ';', '<', '=', '>', '?', '@', '[', '\\', ']':
+		return false
+	}
+	return true
+}
+
+// isAttrName reports whether ch is allowed in the attribute name. It is less strict than token-char
+// to keep attributes accepted by the decoder, but excludes whitespace and the value separator ":".
+func isAttrName(ch byte) bool {
+	return isVisible(ch) && ch != ':'
+}
+
+// isProto reports whether ch is allowed in the media transport protocol: tokens separated by "/".
+func isProto(ch byte) bool {
+	return ch == '/' || isToken(ch)
+}
+
+// isAddress reports whether ch is allowed in connection address including "/" TTL and number of addresses.
+func isAddress(ch byte) bool {
+	return isVisible(ch) && ch < 0x80
+}
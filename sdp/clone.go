@@ -0,0 +1,137 @@
+package sdp
+
+// Clone returns a deep copy of the session description.
+func (s *Session) Clone() *Session {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	if s.Origin != nil {
+		o := *s.Origin
+		c.Origin = &o
+	}
+	c.Email = cloneStrings(s.Email)
+	c.Phone = cloneStrings(s.Phone)
+	if s.Connection != nil {
+		c.Connection = s.Connection.Clone()
+	}
+	c.Bandwidth = cloneBandwidth(s.Bandwidth)
+	if s.TimeZone != nil {
+		c.TimeZone = make([]*TimeZone, len(s.TimeZone))
+		for i, it := range s.TimeZone {
+			z := *it
+			c.TimeZone[i] = &z
+		}
+	}
+	c.Key = cloneKey(s.Key)
+	if s.Timing != nil {
+		t := *s.Timing
+		c.Timing = &t
+	}
+	if s.Repeat != nil {
+		c.Repeat = make([]*Repeat, len(s.Repeat))
+		for i, it := range s.Repeat {
+			r := *it
+			if it.Offsets != nil {
+				r.Offsets = append(r.Offsets[:0:0], it.Offsets...)
+			}
+			c.Repeat[i] = &r
+		}
+	}
+	c.Attributes = s.Attributes.Clone()
+	if s.Media != nil {
+		c.Media = make([]*Media, len(s.Media))
+		for i, it := range s.Media {
+			c.Media[i] = it.Clone()
+		}
+	}
+	return &c
+}
+
+// Clone returns a deep copy of the media description.
+func (m *Media) Clone() *Media {
+	if m == nil {
+		return nil
+	}
+	c := *m
+	if m.Connection != nil {
+		c.Connection = make([]*Connection, len(m.Connection))
+		for i, it := range m.Connection {
+			c.Connection[i] = it.Clone()
+		}
+	}
+	c.Bandwidth = cloneBandwidth(m.Bandwidth)
+	c.Key = cloneKey(m.Key)
+	c.Attributes = m.Attributes.Clone()
+	if m.Format != nil {
+		c.Format = make([]*Format, len(m.Format))
+		for i, it := range m.Format {
+			c.Format[i] = it.Clone()
+		}
+	}
+	return &c
+}
+
+// Clone returns a copy of the connection data.
+func (c *Connection) Clone() *Connection {
+	if c == nil {
+		return nil
+	}
+	r := *c
+	return &r
+}
+
+// Clone returns a deep copy of the media format description.
+func (f *Format) Clone() *Format {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	c.Feedback = cloneStrings(f.Feedback)
+	c.Params = cloneStrings(f.Params)
+	return &c
+}
+
+// Clone returns a deep copy of the attributes.
+func (a Attributes) Clone() Attributes {
+	if a == nil {
+		return nil
+	}
+	c := make(Attributes, len(a))
+	for i, it := range a {
+		v := *it
+		c[i] = &v
+	}
+	return c
+}
+
+func cloneStrings(v []string) []string {
+	if v == nil {
+		return nil
+	}
+	return append(v[:0:0], v...)
+}
+
+func cloneBandwidth(v []*Bandwidth) []*Bandwidth {
+	if v == nil {
+		return nil
+	}
+	c := make([]*Bandwidth, len(v))
+	for i, it := range v {
+		b := *it
+		c[i] = &b
+	}
+	return c
+}
+
+func cloneKey(v []*Key) []*Key {
+	if v == nil {
+		return nil
+	}
+	c := make([]*Key, len(v))
+	for i, it := range v {
+		k := *it
+		c[i] = &k
+	}
+	return c
+}
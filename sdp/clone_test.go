@@ -0,0 +1,63 @@
+package sdp
+
+import "testing"
+
+func TestCloneEqual(t *testing.T) {
+	for _, v := range testVectors {
+		s := v.Session
+		c := s.Clone()
+		(&T{t}).AssertAny(v.Name, c, s)
+		if !c.Equal(s) {
+			t.Fatalf("%s: clone is not equal", v.Name)
+		}
+		for _, m := range c.Media {
+			m.Attributes = append(m.Attributes, NewAttr("mid", "x"))
+			for _, f := range m.Format {
+				f.Name += "x"
+			}
+		}
+		if c.Equal(s) || s.String() == c.String() {
+			t.Fatalf("%s: clone shares media", v.Name)
+		}
+	}
+	a, err := ParseString(`v=0
+o=- 1 1 in ip4 192.0.2.1
+s=-
+c=IN IP4 192.0.2.1
+t=0 0
+a=sendrecv
+a=group:BUNDLE 0
+a=ice-ufrag:abc
+m=audio 9 RTP/AVP 96
+a=rtpmap:96 opus/48000/2
+a=candidate:1 1 UDP 1 192.0.2.1 9 typ host
+a=candidate:2 1 UDP 1 192.0.2.2 9 typ host
+a=mid:0
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ParseString(`v=0
+o=- 1 1 IN IP4 192.0.2.1
+s=-
+c=IN IP4 192.0.2.1
+t=0 0
+a=ice-ufrag:abc
+a=group:BUNDLE 0
+m=audio 9 RTP/AVP 96
+a=rtpmap:96 OPUS/48000/2
+a=mid:0
+a=candidate:2 1 UDP 1 192.0.2.2 9 typ host
+a=candidate:1 1 UDP 1 192.0.2.1 9 typ host
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.Equal(b) {
+		t.Fatal("sessions are not equal")
+	}
+	b.Media[0].Mode = RecvOnly
+	if a.Equal(b) {
+		t.Fatal("sessions are equal")
+	}
+}
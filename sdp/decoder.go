@@ -7,6 +7,7 @@ import (
 	"io"
 	"strconv"
 	"time"
+	"unsafe"
 )
 
 // Parse reads session description from the buffer.
@@ -23,6 +24,11 @@ func ParseString(s string) (*Session, error) {
 type Decoder struct {
 	r lineReader
 	p []string
+
+	// Values of the previous session reused by DecodeInto.
+	origin *Origin
+	conn   *Connection
+	timing *Timing
 }
 
 // NewDecoder returns new decoder that reads from r.
@@ -35,10 +41,41 @@ func NewDecoderString(s string) *Decoder {
 	return &Decoder{r: &stringReader{s: s}}
 }
 
-// Decode encodes the session description.
+// NewDecoderBytes returns new decoder that reads from b without copying.
+// Strings of decoded session descriptions refer to b, so b must not be modified while they are in use.
+func NewDecoderBytes(b []byte) *Decoder {
+	d := new(Decoder)
+	d.ResetBytes(b)
+	return d
+}
+
+// ResetBytes resets the decoder to read from b without copying as NewDecoderBytes, keeping its buffers.
+func (d *Decoder) ResetBytes(b []byte) {
+	v := *(*string)(unsafe.Pointer(&b))
+	if r, ok := d.r.(*stringReader); ok {
+		r.s = v
+	} else {
+		d.r = &stringReader{s: v}
+	}
+}
+
+// Decode decodes the session description.
 func (d *Decoder) Decode() (*Session, error) {
-	line := 0
 	sess := new(Session)
+	if err := d.DecodeInto(sess); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+// DecodeInto decodes the session description into s reusing its media descriptions, formats,
+// attributes and other values allocated by previous calls, see Session.Reset.
+// Values of the previous session description must not be retained by the caller.
+// If an error is returned, s contains a partially decoded session description.
+func (d *Decoder) DecodeInto(sess *Session) error {
+	d.origin, d.conn, d.timing = sess.Origin, sess.Connection, sess.Timing
+	sess.Reset()
+	line := 0
 	var media *Media
 
 	for {
@@ -48,31 +85,73 @@ func (d *Decoder) Decode() (*Session, error) {
 			if err == io.EOF && sess.Origin != nil {
 				break
 			}
-			return nil, err
+			return err
 		}
 		if len(s) == 0 && sess.Origin != nil {
 			break
 		}
 		if len(s) < 2 || s[1] != '=' {
-			return nil, &errDecode{errFormat, line, s}
+			return &errDecode{errFormat, line, s}
 		}
 		f, v := s[0], s[2:]
 		if f == 'm' {
-			media = new(Media)
-			err = d.media(media, f, v)
-			if err == nil {
-				sess.Media = append(sess.Media, media)
+			sess.Media, media = next(sess.Media)
+			media.reset()
+			if err = d.media(media, f, v); err != nil {
+				sess.Media = sess.Media[:len(sess.Media)-1]
 			}
 		} else if media == nil {
 			err = d.session(sess, f, v)
 		} else {
 			err = d.media(media, f, v)
 		}
+		if err != nil {
+			return &errDecode{err, line, s}
+		}
+	}
+	return nil
+}
+
+// DecodeFragment decodes the trickle ICE SDP fragment.
+func (d *Decoder) DecodeFragment() (*Fragment, error) {
+	line := 0
+	frag := new(Fragment)
+	var media *Media
+
+	for {
+		line++
+		s, err := d.r.ReadLine()
+		if err == io.EOF || err == nil && len(s) == 0 {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(s) < 2 || s[1] != '=' {
+			return nil, &errDecode{errFormat, line, s}
+		}
+		f, v := s[0], s[2:]
+		switch {
+		case f == 'm':
+			media = new(Media)
+			err = d.media(media, f, v)
+			if err == nil {
+				frag.Media = append(frag.Media, media)
+			}
+		case media != nil:
+			err = d.media(media, f, v)
+		case f == 'a':
+			var a *Attr
+			frag.Attributes, a = next(frag.Attributes)
+			a.Name, a.Value = d.attr(v)
+		default:
+			err = errUnexpectedField
+		}
 		if err != nil {
 			return nil, &errDecode{err, line, s}
 		}
 	}
-	return sess, nil
+	return frag, nil
 }
 
 func (d *Decoder) session(s *Session, f byte, v string) error {
@@ -84,7 +163,13 @@ func (d *Decoder) session(s *Session, f byte, v string) error {
 		if s.Origin != nil {
 			return errUnexpectedField
 		}
-		s.Origin, err = d.origin(v)
+		o := d.origin
+		if o == nil {
+			o = new(Origin)
+		}
+		if err = d.originValue(o, v); err == nil {
+			s.Origin, d.origin = o, nil
+		}
 	case 's':
 		s.Name = v
 	case 'i':
@@ -99,27 +184,43 @@ func (d *Decoder) session(s *Session, f byte, v string) error {
 		if s.Connection != nil {
 			return errUnexpectedField
 		}
-		s.Connection, err = d.connection(v)
+		c := d.conn
+		if c == nil {
+			c = new(Connection)
+		}
+		if err = d.connection(c, v); err == nil {
+			s.Connection, d.conn = c, nil
+		}
 	case 'b':
-		b, err := d.bandwidth(v)
-		if err != nil {
-			return err
+		var b *Bandwidth
+		s.Bandwidth, b = next(s.Bandwidth)
+		if err = d.bandwidth(b, v); err != nil {
+			s.Bandwidth = s.Bandwidth[:len(s.Bandwidth)-1]
 		}
-		s.Bandwidth = append(s.Bandwidth, b)
 	case 'z':
 		s.TimeZone, err = d.timezone(v)
 	case 'k':
-		s.Key = append(s.Key, d.key(v))
+		var k *Key
+		s.Key, k = next(s.Key)
+		k.Method, k.Value = d.key(v)
 	case 'a':
-		a := d.attr(v)
-		switch a.Name {
+		name, value := d.attr(v)
+		switch name {
 		case Inactive, RecvOnly, SendOnly, SendRecv:
-			s.Mode = a.Name
+			s.Mode = name
 		default:
-			s.Attributes = append(s.Attributes, a)
+			var a *Attr
+			s.Attributes, a = next(s.Attributes)
+			a.Name, a.Value = name, value
 		}
 	case 't':
-		s.Timing, err = d.timing(v)
+		var t Timing
+		if t, err = d.timingValue(v); err == nil && !(t.Start.IsZero() && t.Stop.IsZero()) {
+			if s.Timing = d.timing; s.Timing == nil {
+				s.Timing = new(Timing)
+			}
+			*s.Timing, d.timing = t, nil
+		}
 	case 'r':
 		r, err := d.repeat(v)
 		if err != nil {
@@ -140,28 +241,32 @@ func (d *Decoder) media(m *Media, f byte, v string) error {
 	case 'i':
 		m.Information = v
 	case 'c':
-		conn, err := d.connection(v)
-		if err != nil {
-			return err
+		var c *Connection
+		m.Connection, c = next(m.Connection)
+		if err = d.connection(c, v); err != nil {
+			m.Connection = m.Connection[:len(m.Connection)-1]
 		}
-		m.Connection = append(m.Connection, conn)
 	case 'b':
-		b, err := d.bandwidth(v)
-		if err != nil {
-			return err
+		var b *Bandwidth
+		m.Bandwidth, b = next(m.Bandwidth)
+		if err = d.bandwidth(b, v); err != nil {
+			m.Bandwidth = m.Bandwidth[:len(m.Bandwidth)-1]
 		}
-		m.Bandwidth = append(m.Bandwidth, b)
 	case 'k':
-		m.Key = append(m.Key, d.key(v))
+		var k *Key
+		m.Key, k = next(m.Key)
+		k.Method, k.Value = d.key(v)
 	case 'a':
-		a := d.attr(v)
-		switch a.Name {
+		name, value := d.attr(v)
+		switch name {
 		case Inactive, RecvOnly, SendOnly, SendRecv:
-			m.Mode = a.Name
+			m.Mode = name
 		case "rtpmap", "rtcp-fb", "fmtp":
-			err = d.format(m, a)
+			err = d.format(m, name, value)
 		default:
-			m.Attributes = append(m.Attributes, a)
+			var a *Attr
+			m.Attributes, a = next(m.Attributes)
+			a.Name, a.Value = name, value
 		}
 	default:
 		return errUnexpectedField
@@ -169,47 +274,40 @@ func (d *Decoder) media(m *Media, f byte, v string) error {
 	return err
 }
 
-func (d *Decoder) format(m *Media, a *Attr) error {
-	p, ok := d.fields(a.Value, 2)
+func (d *Decoder) format(m *Media, name, value string) error {
+	p, ok := d.fields(value, 2)
 	if !ok {
 		return nil
 	}
-	var (
-		pt     = p[0]
-		v      = p[1]
-		format []*Format
-	)
+	pt, v := p[0], p[1]
 	if pt == "*" {
-		format = m.Format
-	} else {
-		pt, err := strconv.Atoi(pt)
-		if err != nil {
-			return err
-		}
-		f := m.FormatByPayload(uint8(pt))
-		if f == nil {
-			f = &Format{
-				Payload:   uint8(pt),
-				ClockRate: wellKnownClockRate(pt),
-				Name:      wellKnownName(pt),
-				Channels:  1,
-			}
-			m.Format = append(m.Format, f)
-		}
-		format = append(format, f)
-	}
-	for _, f := range format {
-		switch a.Name {
-		case "rtpmap":
-			err := d.rtpmap(f, v)
-			if err != nil {
+		for _, f := range m.Format {
+			if err := d.formatAttr(f, name, v); err != nil {
 				return err
 			}
-		case "rtcp-fb":
-			f.Feedback = append(f.Feedback, v)
-		case "fmtp":
-			f.Params = append(f.Params, v)
 		}
+		return nil
+	}
+	n, err := strconv.Atoi(pt)
+	if err != nil {
+		return err
+	}
+	f := m.FormatByPayload(uint8(n))
+	if f == nil {
+		m.Format, f = next(m.Format)
+		f.reset(n)
+	}
+	return d.formatAttr(f, name, v)
+}
+
+func (d *Decoder) formatAttr(f *Format, name, v string) error {
+	switch name {
+	case "rtpmap":
+		return d.rtpmap(f, v)
+	case "rtcp-fb":
+		f.Feedback = append(f.Feedback, v)
+	case "fmtp":
+		f.Params = append(f.Params, v)
 	}
 	return nil
 }
@@ -264,55 +362,49 @@ func (d *Decoder) proto(m *Media, v string) error {
 		if err != nil {
 			return err
 		}
-		m.Format = append(m.Format,
-			&Format{
-				Payload:   uint8(pt),
-				ClockRate: wellKnownClockRate(pt),
-				Name:      wellKnownName(pt),
-				Channels:  1,
-			})
+		var f *Format
+		m.Format, f = next(m.Format)
+		f.reset(pt)
 	}
 	return nil
 }
 
-func (d *Decoder) origin(v string) (*Origin, error) {
+func (d *Decoder) originValue(o *Origin, v string) error {
 	p, ok := d.fields(v, 6)
 	if !ok {
-		return nil, errFormat
+		return errFormat
 	}
-	o := new(Origin)
-	o.Username, o.Network, o.Type, o.Address = p[0], p[3], p[4], p[5]
+	*o = Origin{Username: p[0], Network: p[3], Type: p[4], Address: p[5]}
 	var err error
 	if o.SessionID, err = d.int(p[1]); err != nil {
-		return nil, err
+		return err
 	}
 	if o.SessionVersion, err = d.int(p[2]); err != nil {
-		return nil, err
+		return err
 	}
-	return o, nil
+	return checkAddr(o.Network, o.Type, o.Address)
 }
 
-func (d *Decoder) connection(v string) (*Connection, error) {
+func (d *Decoder) connection(c *Connection, v string) error {
 	p, ok := d.fields(v, 3)
 	if !ok {
-		return nil, errFormat
+		return errFormat
 	}
-	c := new(Connection)
-	c.Network, c.Type, c.Address = p[0], p[1], p[2]
+	*c = Connection{Network: p[0], Type: p[1], Address: p[2]}
 	p, _ = d.split(c.Address, '/', 3)
 	switch c.Type {
 	case TypeIPv4:
 		if len(p) > 2 {
 			num, err := d.int(p[2])
 			if err != nil {
-				return nil, err
+				return err
 			}
 			c.AddressNum = int(num)
 		}
 		if len(p) > 1 {
 			ttl, err := d.int(p[1])
 			if err != nil {
-				return nil, err
+				return err
 			}
 			c.Address, c.TTL = p[0], int(ttl)
 		}
@@ -320,27 +412,28 @@ func (d *Decoder) connection(v string) (*Connection, error) {
 		if len(p) > 1 {
 			num, err := d.int(p[1])
 			if err != nil {
-				return nil, err
+				return err
 			}
 			c.Address, c.AddressNum = p[0], int(num)
 		}
 	}
-	return c, nil
+	if err := checkAddr(c.Network, c.Type, c.Address); err != nil {
+		return err
+	}
+	return nil
 }
 
-func (d *Decoder) bandwidth(v string) (*Bandwidth, error) {
+func (d *Decoder) bandwidth(b *Bandwidth, v string) error {
 	p, ok := d.split(v, ':', 2)
 	if !ok {
-		return nil, errFormat
+		return errFormat
 	}
 	val, err := d.int(p[1])
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return &Bandwidth{
-		Type:  p[0],
-		Value: int(val),
-	}, nil
+	b.Type, b.Value = p[0], int(val)
+	return nil
 }
 
 func (d *Decoder) timezone(v string) ([]*TimeZone, error) {
@@ -361,37 +454,34 @@ func (d *Decoder) timezone(v string) ([]*TimeZone, error) {
 	return zone, nil
 }
 
-func (d *Decoder) key(v string) *Key {
+func (d *Decoder) key(v string) (string, string) {
 	if p, ok := d.split(v, ':', 2); ok {
-		return &Key{p[0], p[1]}
+		return p[0], p[1]
 	}
-	return &Key{v, ""}
+	return v, ""
 }
 
-func (d *Decoder) attr(v string) *Attr {
+func (d *Decoder) attr(v string) (string, string) {
 	if p, ok := d.split(v, ':', 2); ok {
-		return &Attr{p[0], p[1]}
+		return p[0], p[1]
 	}
-	return &Attr{v, ""}
+	return v, ""
 }
 
-func (d *Decoder) timing(v string) (*Timing, error) {
+func (d *Decoder) timingValue(v string) (Timing, error) {
 	p, ok := d.fields(v, 2)
 	if !ok {
-		return nil, errFormat
+		return Timing{}, errFormat
 	}
 	start, err := d.time(p[0])
 	if err != nil {
-		return nil, err
+		return Timing{}, err
 	}
 	stop, err := d.time(p[1])
 	if err != nil {
-		return nil, err
-	}
-	if start.IsZero() && stop.IsZero() {
-		return nil, nil
+		return Timing{}, err
 	}
-	return &Timing{start, stop}, nil
+	return Timing{start, stop}, nil
 }
 
 func (d *Decoder) repeat(v string) (*Repeat, error) {
@@ -517,6 +607,7 @@ func (r *reader) ReadLine() (string, error) {
 var errLineTooLong = errors.New("sdp: line is too long")
 var errUnexpectedField = errors.New("unexpected field")
 var errFormat = errors.New("format error")
+var errAddrType = errors.New("address type mismatch")
 
 type errDecode struct {
 	err  error
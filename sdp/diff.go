@@ -0,0 +1,160 @@
+package sdp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Change kinds reported by Diff. Rewrite also reports port and connection changes.
+const (
+	ChangeMediaAdded    = "media-added"    // Media description is added
+	ChangeMediaRemoved  = "media-removed"  // Media description is removed or replaced
+	ChangeMediaDisabled = "media-disabled" // Media port is set to zero
+	ChangeMediaEnabled  = "media-enabled"  // Media port is set from zero
+	ChangePort          = "port-changed"
+	ChangeFormat        = "format-changed" // Media formats, their parameters or order changed
+	ChangeMode          = "mode-changed"
+	ChangeICERestart    = "ice-restart" // ICE username fragment or password changed
+	ChangeFingerprint   = "fingerprint-changed"
+	ChangeConnection    = "connection-changed"
+)
+
+// Change represents a difference between two session descriptions.
+type Change struct {
+	Kind  string // Change kind
+	Media int    // Media description index or -1 for session-level changes
+	Old   string // Old value, if any
+	New   string // New value, if any
+}
+
+// String returns the human-readable change description.
+func (c *Change) String() string {
+	b := make([]byte, 0, 64)
+	if c.Media < 0 {
+		b = append(b, "session"...)
+	} else {
+		b = strconv.AppendInt(append(b, "media "...), int64(c.Media), 10)
+	}
+	b = append(append(b, ": "...), strings.Replace(c.Kind, "-", " ", -1)...)
+	switch {
+	case c.Old != "" && c.New != "":
+		b = append(append(append(append(b, ": "...), c.Old...), " -> "...), c.New...)
+	case c.Old != "":
+		b = append(append(b, ": "...), c.Old...)
+	case c.New != "":
+		b = append(append(b, ": "...), c.New...)
+	}
+	return string(b)
+}
+
+// Changes is a list of changes between two session descriptions.
+type Changes []*Change
+
+// String returns the human-readable description of changes, one per line.
+func (c Changes) String() string {
+	p := make([]string, len(c))
+	for i, it := range c {
+		p[i] = it.String()
+	}
+	return strings.Join(p, "\n")
+}
+
+// Diff returns changes from a to b. Media descriptions are compared by index,
+// a media description with a different type or mid is reported as removed and added.
+// Connection data is compared separately at session level and media level, streaming modes,
+// ICE credentials and DTLS fingerprints are compared using effective values inherited from the session.
+// An ICE restart is reported only if both descriptions specify credentials.
+// A nil session is treated as an empty one, so all media descriptions are reported as added or removed.
+func Diff(a, b *Session) Changes {
+	if a == nil {
+		a = &Session{}
+	}
+	if b == nil {
+		b = &Session{}
+	}
+	var r Changes
+	add := func(kind string, media int, from, to string) {
+		r = append(r, &Change{Kind: kind, Media: media, Old: from, New: to})
+	}
+	if v, w := connString(a.Connection), connString(b.Connection); v != w {
+		add(ChangeConnection, -1, v, w)
+	}
+
+	for i := 0; i < len(a.Media) || i < len(b.Media); i++ {
+		if i >= len(b.Media) {
+			add(ChangeMediaRemoved, i, a.Media[i].Type, "")
+			continue
+		}
+		m := b.Media[i]
+		if i >= len(a.Media) {
+			add(ChangeMediaAdded, i, "", m.Type)
+			continue
+		}
+		o := a.Media[i]
+		if o.Type != m.Type || o.Attributes.Get("mid") != m.Attributes.Get("mid") {
+			add(ChangeMediaRemoved, i, o.Type, "")
+			add(ChangeMediaAdded, i, "", m.Type)
+			continue
+		}
+		switch {
+		case o.Port != 0 && m.Port == 0:
+			add(ChangeMediaDisabled, i, "", "")
+			continue
+		case o.Port == 0 && m.Port != 0:
+			add(ChangeMediaEnabled, i, "", strconv.Itoa(m.Port))
+		case o.Port != m.Port:
+			add(ChangePort, i, strconv.Itoa(o.Port), strconv.Itoa(m.Port))
+		}
+		if o.FormatDescr != m.FormatDescr || !equalFormat(o.Format, m.Format) {
+			add(ChangeFormat, i, formatString(o), formatString(m))
+		}
+		if v, w := a.EffectiveMode(o), b.EffectiveMode(m); v != w {
+			add(ChangeMode, i, v, w)
+		}
+		if v, w := connListString(o.Connection), connListString(m.Connection); v != w {
+			add(ChangeConnection, i, v, w)
+		}
+		if changed(a.EffectiveAttr(o, "ice-ufrag"), b.EffectiveAttr(m, "ice-ufrag")) ||
+			changed(a.EffectiveAttr(o, "ice-pwd"), b.EffectiveAttr(m, "ice-pwd")) {
+			add(ChangeICERestart, i, a.EffectiveAttr(o, "ice-ufrag"), b.EffectiveAttr(m, "ice-ufrag"))
+		}
+		if v, w := a.EffectiveAttr(o, "fingerprint"), b.EffectiveAttr(m, "fingerprint"); v != w {
+			add(ChangeFingerprint, i, v, w)
+		}
+	}
+	return r
+}
+
+// changed reports whether both values are specified and differ.
+func changed(v, w string) bool {
+	return v != "" && w != "" && v != w
+}
+
+func connString(c *Connection) string {
+	if c == nil {
+		return ""
+	}
+	return string(writer(nil).connection(c))
+}
+
+func connListString(c []*Connection) string {
+	p := make([]string, len(c))
+	for i, it := range c {
+		p[i] = connString(it)
+	}
+	return strings.Join(p, ", ")
+}
+
+func formatString(m *Media) string {
+	if m.FormatDescr != "" {
+		return m.FormatDescr
+	}
+	p := make([]string, len(m.Format))
+	for i, f := range m.Format {
+		p[i] = strconv.Itoa(int(f.Payload)) + " " + f.Name + "/" + strconv.Itoa(f.ClockRate)
+		if f.Channels > 1 {
+			p[i] += "/" + strconv.Itoa(f.Channels)
+		}
+	}
+	return strings.Join(p, ", ")
+}
@@ -0,0 +1,53 @@
+package sdp
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	a := testVectors[0].Session
+	b := a.Clone()
+	b.Connection.Address = "224.2.17.13"
+	b.Attributes = append(b.Attributes, NewAttr("ice-ufrag", "abc"))
+	b.Media[0].Port = 0
+	b.Media[1].Format = b.Media[1].Format[1:]
+	b.Media[1].Mode = Inactive
+	b.Media = append(b.Media, &Media{Type: "application", Port: 9, Proto: "UDP/DTLS/SCTP", FormatDescr: "webrtc-datachannel"})
+
+	exp := `session: connection changed: IN IP4 224.2.17.12/127 -> IN IP4 224.2.17.13/127
+media 0: media disabled
+media 1: format changed: 99 h263-1998/90000, 100 H264/90000 -> 100 H264/90000
+media 1: mode changed: recvonly -> inactive
+media 2: media added: application`
+	if got := Diff(a, b).String(); got != exp {
+		t.Fatalf("bad diff:\n%s\nexpected:\n%s", got, exp)
+	}
+	if c := Diff(a, a.Clone()); len(c) != 0 {
+		t.Fatalf("unexpected changes: %s", c)
+	}
+	exp = `session: connection changed: IN IP4 224.2.17.12/127
+media 0: media removed: audio
+media 1: media removed: video`
+	if got := Diff(a, nil).String(); got != exp {
+		t.Fatalf("bad diff with nil session:\n%s\nexpected:\n%s", got, exp)
+	}
+	if c := Diff(nil, a); len(c) != 3 || c[1].Kind != ChangeMediaAdded || c[2].New != "video" {
+		t.Fatalf("bad diff from nil session: %s", c)
+	}
+	if c := Diff(nil, nil); len(c) != 0 {
+		t.Fatalf("unexpected changes: %s", c)
+	}
+
+	a = a.Clone()
+	a.Attributes = append(a.Attributes, NewAttr("ice-ufrag", "abc"), NewAttr("ice-pwd", "secret"))
+	b = a.Clone()
+	b.Attributes = DeleteAttr(b.Attributes, "ice-ufrag", "ice-pwd")
+	for _, m := range b.Media {
+		m.Attributes = append(m.Attributes, NewAttr("ice-ufrag", "abc"), NewAttr("ice-pwd", "secret"))
+	}
+	if c := Diff(a, b); len(c) != 0 {
+		t.Fatalf("unexpected changes for credentials moved to media level: %s", c)
+	}
+	b.Media[1].Attributes = Attributes{NewAttr("ice-ufrag", "def"), NewAttr("ice-pwd", "secret2")}
+	if got := Diff(a, b).String(); got != "media 1: ice restart: abc -> def" {
+		t.Fatalf("bad diff: %s", got)
+	}
+}
@@ -1,54 +1,149 @@
 package sdp
 
 import (
+	"bufio"
 	"io"
 	"strconv"
 	"time"
 )
 
-// An Encoder writes a session description to a buffer.
+// An Encoder writes a session description to a buffer or streams it line by line to a writer.
 type Encoder struct {
-	w io.Writer
-	b writer
+	// LF enables LF line endings instead of CRLF.
+	LF bool
+	// OmitTrailingNewline disables the line ending after the last line.
+	OmitTrailingNewline bool
+	// Strict makes the encoder fail on missing mandatory values instead of writing defaults
+	// such as "-" for empty strings or "IN IP4 127.0.0.1" for empty transport fields.
+	// Nil timing is valid and encoded as "t=0 0", which the decoder also returns as nil timing.
+	Strict bool
+
+	w   *bufio.Writer
+	b   writer
+	err error
 }
 
 // NewEncoder returns a new encoder that writes to w.
+// If w is nil, the encoder writes to its buffer available by Bytes.
 func NewEncoder(w io.Writer) *Encoder {
 	return NewEncoderSize(w, 1024)
 }
 
-// NewEncoderSize returns a new encoder that writes to w.
+// NewEncoderSize returns a new encoder that writes to w with the buffer of at least the specified size.
 func NewEncoderSize(w io.Writer, size int) *Encoder {
-	return &Encoder{w: w, b: make([]byte, 0, size)}
+	e := &Encoder{b: make([]byte, 0, size)}
+	if w != nil {
+		e.w = bufio.NewWriterSize(w, size)
+	}
+	return e
 }
 
 // Encode encodes the session description.
+// It returns EncodeError if a field contains characters not allowed by RFC 4566 grammar,
+// for example line breaks which could inject extra lines.
+// If the encoder has a writer, lines are written as they are encoded,
+// so the whole session description is never kept in memory.
 func (e *Encoder) Encode(s *Session) error {
 	e.Reset()
-	e.b = e.b.session(s)
+	if err := e.check(s); err != nil {
+		return err
+	}
+	if e.w == nil {
+		e.b = e.end(e.b.session(s, nil))
+		return nil
+	}
+	e.b = e.end(e.b.session(s, e.emit))
+	return e.Flush()
+}
+
+// EncodeFragment encodes the trickle ICE SDP fragment.
+func (e *Encoder) EncodeFragment(f *Fragment) error {
+	e.Reset()
+	c := &checker{strict: e.Strict}
+	if err := c.fragment(f); err != nil {
+		return err
+	}
+	if e.b = e.b.fragment(f); len(e.b) > 0 {
+		e.b = e.end(e.b)
+	}
 	if e.w != nil {
 		return e.Flush()
 	}
 	return nil
 }
 
+// Len returns the exact length of the encoded session description without encoding it to a buffer,
+// for example to set Content-Length header before streaming the session description.
+func (e *Encoder) Len(s *Session) (int, error) {
+	if err := e.check(s); err != nil {
+		return 0, err
+	}
+	n := 0
+	count := func(w writer) writer {
+		if e.LF {
+			w = w.lf()
+		}
+		n += len(w)
+		return w[:0]
+	}
+	w := e.end(make(writer, 0, 256).session(s, count))
+	return n + len(w), nil
+}
+
+// AppendSession appends the encoded session description to dst and returns the extended buffer.
+// Fields are not validated, use Encoder.Encode for session descriptions built from untrusted input.
+func AppendSession(dst []byte, s *Session) []byte {
+	return writer(dst).session(s, nil).crlf()
+}
+
+func (e *Encoder) check(s *Session) error {
+	c := &checker{strict: e.Strict}
+	return c.session(s)
+}
+
+// end adds the trailing line ending and converts line endings as configured.
+func (e *Encoder) end(w writer) writer {
+	if !e.OmitTrailingNewline {
+		w = w.crlf()
+	}
+	if e.LF {
+		w = w.lf()
+	}
+	return w
+}
+
+// emit writes encoded lines to w and returns the empty buffer for next lines.
+func (e *Encoder) emit(w writer) writer {
+	if e.LF {
+		w = w.lf()
+	}
+	if e.err == nil {
+		_, e.err = e.w.Write(w)
+	}
+	return w[:0]
+}
+
 // Flush writes encoded bytes to w.
 func (e *Encoder) Flush() error {
-	if b := e.b; len(b) > 0 {
-		n, err := e.w.Write(b)
-		e.b = b[:copy(b, b[n:])]
-		return err
+	if e.w == nil {
+		return nil
 	}
-	return nil
+	e.b = e.emit(e.b)
+	if e.err == nil {
+		e.err = e.w.Flush()
+	}
+	err := e.err
+	e.err = nil
+	return err
 }
 
 // Reset resets encoder state to be empty.
 func (e *Encoder) Reset() {
 	e.b = e.b[:0]
-	//	e.pos, e.newline = 0, false
+	e.err = nil
 }
 
-// Bytes returns encoded bytes of the last session description.
+// Bytes returns encoded bytes of the last session description if the encoder has no writer.
 // The bytes stop being valid at the next encoder call.
 func (e *Encoder) Bytes() []byte {
 	return e.b
@@ -88,7 +183,28 @@ func (w writer) crlf() writer {
 	return append(w, '\r', '\n')
 }
 
-func (w writer) session(s *Session) writer {
+// lf replaces CRLF line endings with LF.
+func (w writer) lf() writer {
+	r := w[:0]
+	for i, ch := range w {
+		if ch != '\r' || i+1 == len(w) || w[i+1] != '\n' {
+			r = append(r, ch)
+		}
+	}
+	return r
+}
+
+// emitFunc consumes encoded lines of a large session description and returns the buffer for next lines.
+type emitFunc func(w writer) writer
+
+func (w writer) emit(fn emitFunc) writer {
+	if fn == nil {
+		return w
+	}
+	return fn(w)
+}
+
+func (w writer) session(s *Session, fn emitFunc) writer {
 	w = w.str("v=").int(int64(s.Version))
 	if s.Origin != nil {
 		w = w.add('o').origin(s.Origin)
@@ -126,19 +242,34 @@ func (w writer) session(s *Session) writer {
 		w = w.add('a').str(s.Mode)
 	}
 	for _, it := range s.Attributes {
-		w = w.add('a').attr(it)
+		w = w.emit(fn).add('a').attr(it)
 	}
 	for _, it := range s.Media {
-		w = w.media(it)
+		w = w.emit(fn).media(it, fn)
+	}
+	return w
+}
+
+func (w writer) fragment(f *Fragment) writer {
+	n := len(w)
+	for _, it := range f.Attributes {
+		w = w.add('a').attr(it)
 	}
-	return w.crlf()
+	for _, it := range f.Media {
+		w = w.media(it, nil)
+	}
+	if len(w) == n {
+		return w
+	}
+	// Fragment has no version line, so drop the line break before the first line.
+	return append(w[:n], w[n+2:]...)
 }
 
 func (w writer) origin(o *Origin) writer {
 	return w.str(strdef(o.Username, "-")).sp().int(o.SessionID).sp().int(o.SessionVersion).sp().transport(o.Network, o.Type, o.Address)
 }
 
-func (w writer) media(m *Media) writer {
+func (w writer) media(m *Media, fn emitFunc) writer {
 	w = w.add('m').str(m.Type).sp().int(int64(m.Port))
 	if m.PortNum > 0 {
 		w = w.char('/').int(int64(m.PortNum))
@@ -166,13 +297,13 @@ func (w writer) media(m *Media) writer {
 		w = w.add('k').key(it)
 	}
 	for _, it := range m.Format {
-		w = w.format(it)
+		w = w.emit(fn).format(it)
 	}
 	if m.Mode != "" {
 		w = w.add('a').str(m.Mode)
 	}
 	for _, it := range m.Attributes {
-		w = w.add('a').attr(it)
+		w = w.emit(fn).add('a').attr(it)
 	}
 	return w
 }
@@ -0,0 +1,159 @@
+package sdp
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestEncoderOptions(t *testing.T) {
+	s := &Session{
+		Origin: &Origin{Username: "-", SessionID: 1, SessionVersion: 1, Network: "IN", Type: "IP4", Address: "192.0.2.1"},
+		Name:   "-",
+		Timing: &Timing{},
+		Media:  []*Media{{Type: "audio", Port: 5004, Proto: "RTP/AVP", Format: []*Format{{Payload: 0}}}},
+	}
+	e := NewEncoder(nil)
+	e.LF, e.OmitTrailingNewline = true, true
+	if err := e.Encode(s); err != nil {
+		t.Fatal(err)
+	}
+	if v := e.String(); v != "v=0\no=- 1 1 IN IP4 192.0.2.1\ns=-\nt=0 0\nm=audio 5004 RTP/AVP 0" {
+		t.Errorf("bad encoding: %q", v)
+	}
+	e = NewEncoder(nil)
+	e.Strict = true
+	if err := e.Encode(s); err == nil || err.Error() != "sdp: missing value in c= of media 0" {
+		t.Errorf("expected missing connection error: %v", err)
+	}
+	s.Connection = &Connection{Network: "IN", Type: "IP4"}
+	if err := e.Encode(s); err == nil || err.Error() != "sdp: missing value in c= address" {
+		t.Errorf("expected missing connection address error: %v", err)
+	}
+	s.Connection.Address = "192.0.2.1"
+	if err := e.Encode(s); err != nil {
+		t.Fatal(err)
+	}
+	if v := e.String(); v != "v=0\r\no=- 1 1 IN IP4 192.0.2.1\r\ns=-\r\nc=IN IP4 192.0.2.1\r\nt=0 0\r\nm=audio 5004 RTP/AVP 0\r\n" {
+		t.Errorf("bad encoding: %q", v)
+	}
+	s.Timing = nil
+	if err := e.Encode(s); err != nil {
+		t.Fatal(err)
+	}
+	if v := e.String(); !strings.Contains(v, "\r\nt=0 0\r\n") {
+		t.Errorf("expected unbounded timing: %q", v)
+	}
+}
+
+func TestEncoderStrictRoundTrip(t *testing.T) {
+	const v = "v=0\r\no=- 1 1 IN IP4 192.0.2.1\r\ns=-\r\nc=IN IP4 192.0.2.1\r\nt=0 0\r\nm=audio 5004 RTP/AVP 0\r\n"
+	s, err := ParseString(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := NewEncoder(nil)
+	e.Strict = true
+	if err := e.Encode(s); err != nil {
+		t.Fatal(err)
+	}
+	if e.String() != v {
+		t.Errorf("bad encoding: %q", e.String())
+	}
+}
+
+func TestEncoderAttrNames(t *testing.T) {
+	const v = "v=0\r\no=- 1 1 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\na=x-vendor/feature:1\r\na=label[0]\r\n" +
+		"m=audio 5004 RTP/AVP 0\r\na=3gpp_sync_info:No Sync\r\na=x@example.com=yes\r\n"
+	s, err := ParseString(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := NewEncoder(nil)
+	if err = e.Encode(s); err != nil {
+		t.Fatal(err)
+	}
+	if e.String() != v {
+		t.Errorf("bad encoding: %q", e.String())
+	}
+	s.Attributes = Attributes{NewAttr("x y", "")}
+	if err = e.Encode(s); err == nil || err.Error() != "sdp: invalid character in a= name" {
+		t.Errorf("expected invalid attribute name error: %v", err)
+	}
+}
+
+func TestEncoderInjection(t *testing.T) {
+	for _, it := range []struct {
+		fn  func(s *Session)
+		err string
+	}{
+		{func(s *Session) { s.Name = "x\r\na=injected" }, "sdp: invalid character in s="},
+		{func(s *Session) { s.Information = "x\x00" }, "sdp: invalid character in i="},
+		{func(s *Session) { s.Origin.Username = "alice smith" }, "sdp: invalid character in o= username"},
+		{func(s *Session) { s.Attributes = Attributes{NewAttr("tool", "x\nm=video")} }, "sdp: invalid character in a=tool"},
+		{func(s *Session) { s.Attributes = Attributes{NewAttr("x:y", "")} }, "sdp: invalid character in a= name"},
+		{func(s *Session) { s.Media[0].Format[0].Params = []string{"mode=30\r\n"} }, "sdp: invalid character in a=fmtp of media 0"},
+		{func(s *Session) { s.Media[0].Connection[0].Address = "192.0.2.1 x" }, "sdp: invalid character in c= address of media 0"},
+		{func(s *Session) { s.Media[0].Proto = "RTP/AVP 96" }, "sdp: invalid character in m= proto of media 0"},
+		{func(s *Session) { s.Media[0].Mode = "recvonly\r\n" }, "sdp: invalid value in a= mode of media 0"},
+		{func(s *Session) { s.Media[0].Port = 65536 }, "sdp: invalid value in m= port of media 0"},
+	} {
+		s := &Session{
+			Origin: &Origin{Username: "-", Network: "IN", Type: "IP4", Address: "192.0.2.1"},
+			Media: []*Media{{
+				Type: "audio", Port: 5004, Proto: "RTP/AVP", Format: []*Format{{Payload: 0}},
+				Connection: []*Connection{{Network: "IN", Type: "IP4", Address: "192.0.2.1"}},
+			}},
+		}
+		it.fn(s)
+		err := NewEncoder(nil).Encode(s)
+		if e, ok := err.(*EncodeError); !ok || e.Error() != it.err {
+			t.Errorf("bad error: %v, expected %s", err, it.err)
+		}
+	}
+}
+
+type chunkWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *chunkWriter) Write(b []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(b)
+}
+
+func TestEncoderStream(t *testing.T) {
+	s, err := ParseString(testVectors[0].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		s.Media[0].Attributes = append(s.Media[0].Attributes, NewAttr("candidate", "1 1 UDP 2130706431 192.0.2.1 "+strconv.Itoa(10000+i)+" typ host"))
+	}
+	exp := s.String()
+	if v := string(AppendSession([]byte("INVITE\r\n\r\n"), s)); v != "INVITE\r\n\r\n"+exp {
+		t.Errorf("bad appended session: %q", v)
+	}
+	w := &chunkWriter{}
+	e := NewEncoderSize(w, 256)
+	if n, err := e.Len(s); err != nil || n != len(exp) {
+		t.Errorf("bad length: %d, expected %d", n, len(exp))
+	}
+	if err := e.Encode(s); err != nil {
+		t.Fatal(err)
+	}
+	if w.String() != exp || w.writes < 2 || len(e.Bytes()) != 0 {
+		t.Errorf("bad streamed session in %d writes: %q", w.writes, w.String())
+	}
+	w.Reset()
+	e.LF, e.OmitTrailingNewline = true, true
+	exp = strings.TrimSuffix(strings.Replace(exp, "\r\n", "\n", -1), "\n")
+	if n, err := e.Len(s); err != nil || n != len(exp) {
+		t.Errorf("bad length: %d, expected %d", n, len(exp))
+	}
+	if err := e.Encode(s); err != nil || w.String() != exp {
+		t.Errorf("bad streamed session: %q %v", w.String(), err)
+	}
+}
@@ -0,0 +1,223 @@
+package sdp
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Equal reports whether the session descriptions are semantically equal.
+// Network and address types, bandwidth types, key methods and encoding names are compared
+// case-insensitively, empty values are replaced with defaults written by Encoder,
+// and streaming modes are compared per media with session-level mode inherited.
+// The order of attributes with different names and of bandwidth lines is insignificant,
+// as well as the order of "candidate", "ssrc", "ssrc-group", "extmap" and "group" attributes.
+func (s *Session) Equal(o *Session) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	if s.Version != o.Version || s.Name != o.Name || s.Information != o.Information || s.URI != o.URI {
+		return false
+	}
+	if !equalStrings(s.Email, o.Email) || !equalStrings(s.Phone, o.Phone) {
+		return false
+	}
+	if !s.Origin.equal(o.Origin) || !s.Connection.equal(o.Connection) {
+		return false
+	}
+	if !equalBandwidth(s.Bandwidth, o.Bandwidth) || !equalKey(s.Key, o.Key) || !s.Timing.equal(o.Timing) {
+		return false
+	}
+	if len(s.TimeZone) != len(o.TimeZone) || len(s.Repeat) != len(o.Repeat) || len(s.Media) != len(o.Media) {
+		return false
+	}
+	for i, it := range s.TimeZone {
+		if !it.Time.Equal(o.TimeZone[i].Time) || it.Offset != o.TimeZone[i].Offset {
+			return false
+		}
+	}
+	for i, it := range s.Repeat {
+		r := o.Repeat[i]
+		if it.Interval != r.Interval || it.Duration != r.Duration || len(it.Offsets) != len(r.Offsets) {
+			return false
+		}
+		for j, v := range it.Offsets {
+			if v != r.Offsets[j] {
+				return false
+			}
+		}
+	}
+	if !equalAttr(s.Attributes, o.Attributes) {
+		return false
+	}
+	if len(s.Media) == 0 && strdef(s.Mode, SendRecv) != strdef(o.Mode, SendRecv) {
+		return false
+	}
+	for i, it := range s.Media {
+		m := o.Media[i]
+		if !it.equal(m) || s.EffectiveMode(it) != o.EffectiveMode(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Media) equal(o *Media) bool {
+	if m.Type != o.Type || m.Port != o.Port || m.Proto != o.Proto || m.Information != o.Information {
+		return false
+	}
+	if count(m.PortNum) != count(o.PortNum) || m.FormatDescr != o.FormatDescr {
+		return false
+	}
+	if len(m.Connection) != len(o.Connection) || !equalFormat(m.Format, o.Format) {
+		return false
+	}
+	for i, it := range m.Connection {
+		if !it.equal(o.Connection[i]) {
+			return false
+		}
+	}
+	return equalBandwidth(m.Bandwidth, o.Bandwidth) && equalKey(m.Key, o.Key) && equalAttr(m.Attributes, o.Attributes)
+}
+
+func equalFormat(a, b []*Format) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, it := range a {
+		if !it.equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Format) equal(o *Format) bool {
+	return f.Payload == o.Payload && f.match(o) && equalSet(f.Feedback, o.Feedback) && equalStrings(f.Params, o.Params)
+}
+
+func (o *Origin) equal(v *Origin) bool {
+	if o == nil || v == nil {
+		return o == v
+	}
+	return o.Username == v.Username && o.SessionID == v.SessionID && o.SessionVersion == v.SessionVersion &&
+		equalTransport(o.Network, o.Type, o.Address, v.Network, v.Type, v.Address)
+}
+
+func (c *Connection) equal(v *Connection) bool {
+	if c == nil || v == nil {
+		return c == v
+	}
+	return c.TTL == v.TTL && count(c.AddressNum) == count(v.AddressNum) &&
+		equalTransport(c.Network, c.Type, c.Address, v.Network, v.Type, v.Address)
+}
+
+func (t *Timing) equal(v *Timing) bool {
+	if t == nil {
+		t = &Timing{}
+	}
+	if v == nil {
+		v = &Timing{}
+	}
+	return t.Start.Equal(v.Start) && t.Stop.Equal(v.Stop)
+}
+
+// count returns the number of ports or addresses, which is one if omitted.
+func count(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+func equalTransport(network, typ, addr, network2, typ2, addr2 string) bool {
+	return strings.EqualFold(strdef(network, NetworkInternet), strdef(network2, NetworkInternet)) &&
+		strings.EqualFold(strdef(typ, TypeIPv4), strdef(typ2, TypeIPv4)) &&
+		strings.EqualFold(strdef(addr, "127.0.0.1"), strdef(addr2, "127.0.0.1"))
+}
+
+func equalBandwidth(a, b []*Bandwidth) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x, y := make([]string, len(a)), make([]string, len(b))
+	for i, it := range a {
+		x[i] = strings.ToUpper(it.Type) + ":" + strconv.Itoa(it.Value)
+	}
+	for i, it := range b {
+		y[i] = strings.ToUpper(it.Type) + ":" + strconv.Itoa(it.Value)
+	}
+	return equalSet(x, y)
+}
+
+func equalKey(a, b []*Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, it := range a {
+		if !strings.EqualFold(it.Method, b[i].Method) || it.Value != b[i].Value {
+			return false
+		}
+	}
+	return true
+}
+
+// unorderedAttr lists attributes that may be repeated in any order.
+var unorderedAttr = map[string]bool{
+	"candidate":  true,
+	"ssrc":       true,
+	"ssrc-group": true,
+	"extmap":     true,
+	"group":      true,
+}
+
+func equalAttr(a, b Attributes) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x, y := groupAttr(a), groupAttr(b)
+	if len(x) != len(y) {
+		return false
+	}
+	for name, v := range x {
+		if unorderedAttr[name] {
+			if !equalSet(v, y[name]) {
+				return false
+			}
+		} else if !equalStrings(v, y[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func groupAttr(attrs Attributes) map[string][]string {
+	r := make(map[string][]string)
+	for _, it := range attrs {
+		r[it.Name] = append(r[it.Name], it.Value)
+	}
+	return r
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, it := range a {
+		if it != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x := append(a[:0:0], a...)
+	y := append(b[:0:0], b...)
+	sort.Strings(x)
+	sort.Strings(y)
+	return equalStrings(x, y)
+}
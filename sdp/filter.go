@@ -0,0 +1,131 @@
+package sdp
+
+import (
+	"errors"
+	"strings"
+)
+
+// Source filter modes.
+const (
+	FilterInclude = "incl"
+	FilterExclude = "excl"
+)
+
+// SourceFilter contains a source filter ("a=source-filter") as described in RFC 4570.
+type SourceFilter struct {
+	Mode    string   // Filter mode, "incl" or "excl"
+	Network string   // Network type or "*"
+	Type    string   // Address type or "*"
+	Address string   // Destination address or "*"
+	Sources []string // Source addresses
+}
+
+// ParseSourceFilter parses the source filter attribute value.
+func ParseSourceFilter(v string) (*SourceFilter, error) {
+	p := strings.Fields(v)
+	if len(p) < 5 {
+		return nil, errSourceFilter
+	}
+	f := &SourceFilter{Mode: p[0], Network: p[1], Type: p[2], Address: p[3], Sources: p[4:]}
+	if f.Mode != FilterInclude && f.Mode != FilterExclude {
+		return nil, errSourceFilter
+	}
+	return f, nil
+}
+
+// String returns the source filter attribute value.
+func (f *SourceFilter) String() string {
+	return strings.Join(append([]string{f.Mode, f.Network, f.Type, f.Address}, f.Sources...), " ")
+}
+
+// SourceFilters returns session-level source filters.
+func (s *Session) SourceFilters() ([]*SourceFilter, error) {
+	return sourceFilters(s.Attributes)
+}
+
+// SourceFilters returns media-level source filters.
+func (m *Media) SourceFilters() ([]*SourceFilter, error) {
+	return sourceFilters(m.Attributes)
+}
+
+// AddSourceFilter adds the session-level source filter.
+func (s *Session) AddSourceFilter(f *SourceFilter) {
+	s.Attributes = append(s.Attributes, NewAttr("source-filter", " "+f.String()))
+}
+
+// AddSourceFilter adds the media-level source filter.
+func (m *Media) AddSourceFilter(f *SourceFilter) {
+	m.Attributes = append(m.Attributes, NewAttr("source-filter", " "+f.String()))
+}
+
+func sourceFilters(attrs Attributes) ([]*SourceFilter, error) {
+	var r []*SourceFilter
+	for _, a := range attrs {
+		if a.Name != "source-filter" {
+			continue
+		}
+		f, err := ParseSourceFilter(a.Value)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, f)
+	}
+	return r, nil
+}
+
+// SourcePermitted reports whether the media stream sent to the destination connection address
+// may be received from the source address. Media-level source filters replace session-level ones.
+// The source is excluded if it is listed by a matching "excl" filter, and it must be listed by
+// one of matching "incl" filters, if any. Filters with "*" destination address match all
+// connection addresses of the type.
+func (s *Session) SourcePermitted(m *Media, dest *Connection, src string) (bool, error) {
+	filters, err := m.SourceFilters()
+	if err == nil && len(filters) == 0 {
+		filters, err = s.SourceFilters()
+	}
+	if err != nil {
+		return false, err
+	}
+	incl, ok := false, false
+	for _, f := range filters {
+		if !f.match(dest) {
+			continue
+		}
+		listed := f.listed(src)
+		switch f.Mode {
+		case FilterExclude:
+			if listed {
+				return false, nil
+			}
+		case FilterInclude:
+			incl = true
+			ok = ok || listed
+		}
+	}
+	return ok || !incl, nil
+}
+
+func (f *SourceFilter) match(c *Connection) bool {
+	if f.Network != "*" && f.Network != c.Network || f.Type != "*" && f.Type != c.Type {
+		return false
+	}
+	return f.Address == "*" || equalAddr(f.Address, c.Address)
+}
+
+func (f *SourceFilter) listed(src string) bool {
+	for _, it := range f.Sources {
+		if equalAddr(it, src) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalAddr(a, b string) bool {
+	if x, y := parseAddr(a), parseAddr(b); x.IsValid() && y.IsValid() {
+		return x.Unmap() == y.Unmap()
+	}
+	return strings.EqualFold(a, b)
+}
+
+var errSourceFilter = errors.New("sdp: invalid source filter")
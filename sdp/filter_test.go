@@ -0,0 +1,42 @@
+package sdp
+
+import "testing"
+
+func TestSourceFilter(t *testing.T) {
+	s, err := ParseString("v=0\r\no=- 1 1 IN IP4 192.0.2.1\r\ns=-\r\nc=IN IP4 232.3.4.5/127\r\nt=0 0\r\n" +
+		"a=source-filter: incl IN IP4 232.3.4.5 192.0.2.10 192.0.2.11\r\n" +
+		"m=video 5004 RTP/AVP 33\r\n" +
+		"m=audio 5006 RTP/AVP 0\r\nc=IN IP4 232.3.4.6/127\r\na=source-filter: excl IN * * 192.0.2.12\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := s.SourceFilters()
+	if err != nil || len(f) != 1 || f[0].String() != "incl IN IP4 232.3.4.5 192.0.2.10 192.0.2.11" {
+		t.Fatalf("bad source filters: %v %v", f, err)
+	}
+	video, audio := s.Media[0], s.Media[1]
+	for _, it := range []struct {
+		m    *Media
+		dest *Connection
+		src  string
+		ok   bool
+	}{
+		{video, s.Connection, "192.0.2.10", true},
+		{video, s.Connection, "192.0.2.12", false},
+		{video, audio.Connection[0], "192.0.2.12", true},
+		{audio, audio.Connection[0], "192.0.2.10", true},
+		{audio, audio.Connection[0], "192.0.2.12", false},
+	} {
+		ok, err := s.SourcePermitted(it.m, it.dest, it.src)
+		if err != nil || ok != it.ok {
+			t.Errorf("bad permission of %s for %s: %v %v", it.src, it.dest.Address, ok, err)
+		}
+	}
+	audio.AddSourceFilter(&SourceFilter{Mode: FilterInclude, Network: "IN", Type: "IP4", Address: "232.3.4.6", Sources: []string{"192.0.2.13"}})
+	if ok, _ := s.SourcePermitted(audio, audio.Connection[0], "192.0.2.10"); ok {
+		t.Errorf("source must not be permitted")
+	}
+	if _, err := ParseSourceFilter("any IN IP4 232.3.4.5 192.0.2.10"); err == nil {
+		t.Errorf("expected error")
+	}
+}
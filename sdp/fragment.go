@@ -0,0 +1,154 @@
+package sdp
+
+import "errors"
+
+// FragmentContentType is the media type for a trickle ICE SDP fragment.
+const FragmentContentType = "application/trickle-ice-sdpfrag"
+
+// Fragment represents an SDP fragment used for trickle ICE as defined in RFC 8840.
+// It contains session-level attributes such as "ice-ufrag", "ice-pwd" and "ice-options",
+// and media descriptions with "mid", "candidate" and "end-of-candidates" attributes.
+type Fragment struct {
+	Attributes Attributes // Session-level Attributes ("a=")
+	Media      []*Media   // Media Descriptions ("m=")
+}
+
+// ParseFragment reads the SDP fragment from the buffer.
+func ParseFragment(b []byte) (*Fragment, error) {
+	return ParseFragmentString(string(b))
+}
+
+// ParseFragmentString reads the SDP fragment from the string.
+func ParseFragmentString(s string) (*Fragment, error) {
+	return NewDecoderString(s).DecodeFragment()
+}
+
+// String returns the encoded SDP fragment as string.
+func (f *Fragment) String() string {
+	return string(f.Bytes())
+}
+
+// Bytes returns the encoded SDP fragment as buffer.
+// Fields are not validated, use Encoder.EncodeFragment for fragments built from untrusted input.
+func (f *Fragment) Bytes() []byte {
+	w := writer(make([]byte, 0, 256)).fragment(f)
+	if len(w) > 0 {
+		w = w.crlf()
+	}
+	return w
+}
+
+// Fragment returns the SDP fragment with ICE credentials, options and all candidates of the session.
+func (s *Session) Fragment() *Fragment {
+	f := &Fragment{Attributes: filterAttr(nil, s.Attributes, "ice-ufrag", "ice-pwd", "ice-options", "end-of-candidates")}
+	for _, m := range s.Media {
+		it := &Media{
+			Type:        m.Type,
+			Port:        9,
+			Proto:       m.Proto,
+			FormatDescr: m.FormatDescr,
+			Attributes:  filterAttr(nil, m.Attributes, "mid", "ice-ufrag", "ice-pwd", "candidate", "end-of-candidates"),
+		}
+		if it.FormatDescr == "" {
+			var b writer
+			for i, p := range m.Format {
+				if i > 0 {
+					b = b.sp()
+				}
+				b = b.int(int64(p.Payload))
+			}
+			it.FormatDescr = string(b)
+		}
+		f.Media = append(f.Media, it)
+	}
+	return f
+}
+
+// Merge applies candidates and end-of-candidates indications of the SDP fragment to the session.
+// Media descriptions are matched by "mid" attribute or by m-line index if the fragment has no mid.
+// It returns an error if the fragment ICE credentials do not match the session, e.g. after an ICE restart.
+func (s *Session) Merge(f *Fragment) error {
+	for i, fm := range f.Media {
+		m := s.MediaByMid(fm.Attributes.Get("mid"), i)
+		if m == nil {
+			return errFragmentMedia
+		}
+		for _, name := range []string{"ice-ufrag", "ice-pwd"} {
+			v := fm.Attributes.Get(name)
+			if v == "" {
+				v = f.Attributes.Get(name)
+			}
+			if v == "" {
+				continue
+			}
+			cur := m.Attributes.Get(name)
+			if cur == "" {
+				cur = s.Attributes.Get(name)
+			}
+			if cur != "" && cur != v {
+				return errFragmentCredentials
+			}
+		}
+	}
+	for i, fm := range f.Media {
+		m := s.MediaByMid(fm.Attributes.Get("mid"), i)
+		for _, a := range fm.Attributes {
+			switch a.Name {
+			case "candidate", "end-of-candidates":
+				if !hasAttr(m.Attributes, a) {
+					m.Attributes = append(m.Attributes, NewAttr(a.Name, a.Value))
+				}
+			}
+		}
+	}
+	if f.Attributes.Has("end-of-candidates") && !s.Attributes.Has("end-of-candidates") {
+		s.Attributes = append(s.Attributes, NewAttrFlag("end-of-candidates"))
+	}
+	return nil
+}
+
+// MediaByMid returns the media description identified by the "a=mid" attribute value.
+// If mid is empty or the session does not use mids, it returns the media description by index,
+// which is the m-line index of the fragment media. It returns nil if no media description matches.
+func (s *Session) MediaByMid(mid string, index int) *Media {
+	if mid == "" {
+		if index < len(s.Media) {
+			return s.Media[index]
+		}
+		return nil
+	}
+	for _, m := range s.Media {
+		if m.Attributes.Get("mid") == mid {
+			return m
+		}
+	}
+	// Fall back to the m-line index if the session does not use mids.
+	if index < len(s.Media) && !s.Media[index].Attributes.Has("mid") {
+		return s.Media[index]
+	}
+	return nil
+}
+
+func filterAttr(dst, attrs Attributes, name ...string) Attributes {
+	for _, it := range attrs {
+		for _, v := range name {
+			if it.Name == v {
+				dst = append(dst, NewAttr(it.Name, it.Value))
+				break
+			}
+		}
+	}
+	return dst
+}
+
+func hasAttr(attrs Attributes, a *Attr) bool {
+	for _, it := range attrs {
+		if it.Name == a.Name && it.Value == a.Value {
+			return true
+		}
+	}
+	return false
+}
+
+var errFragmentMedia = errors.New("sdp: fragment media description not found")
+var errFragmentCredentials = errors.New("sdp: fragment ice credentials mismatch")
@@ -0,0 +1,76 @@
+package sdp
+
+import (
+	"strings"
+	"testing"
+)
+
+const testFragment = `a=ice-ufrag:8hhY
+a=ice-pwd:asd88fgpdd777uzjYhagZg
+m=audio 9 RTP/AVP 0
+a=mid:1
+a=candidate:1 1 UDP 2130706431 198.51.100.1 49170 typ host
+a=candidate:2 1 UDP 1694498815 192.0.2.3 51372 typ srflx raddr 198.51.100.1 rport 49170
+a=end-of-candidates
+`
+
+func TestFragment(t *testing.T) {
+	f, err := ParseFragmentString(testFragment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Attributes) != 2 || len(f.Media) != 1 || len(f.Media[0].Attributes) != 4 {
+		t.Fatalf("bad fragment: %s", dump(f))
+	}
+	if got := strings.Replace(f.String(), "\r\n", "\n", -1); got != testFragment {
+		t.Fatalf("bad encoded fragment: %s", got)
+	}
+	sess, err := ParseString(`v=0
+o=- 0 1 IN IP4 127.0.0.1
+s=-
+c=IN IP4 127.0.0.1
+t=0 0
+a=ice-ufrag:8hhY
+a=ice-pwd:asd88fgpdd777uzjYhagZg
+m=audio 49170 RTP/AVP 0
+a=mid:1
+a=candidate:1 1 UDP 2130706431 198.51.100.1 49170 typ host
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sess.Merge(f); err != nil {
+		t.Fatal(err)
+	}
+	attrs := sess.Media[0].Attributes
+	if len(attrs) != 4 || !attrs.Has("end-of-candidates") {
+		t.Fatalf("bad merged attributes: %s", dump(attrs))
+	}
+	if got := sess.Fragment().String(); got != f.String() {
+		t.Fatalf("bad session fragment: %s", got)
+	}
+	f.Attributes[0].Value = "restart"
+	if err = sess.Merge(f); err == nil {
+		t.Fatal("expected credentials mismatch")
+	}
+}
+
+func TestMediaByMid(t *testing.T) {
+	s := &Session{Media: []*Media{
+		{Type: "audio", Attributes: Attributes{NewAttr("mid", "a")}},
+		{Type: "video", Attributes: Attributes{NewAttr("mid", "v")}},
+	}}
+	if m := s.MediaByMid("v", 0); m != s.Media[1] {
+		t.Error("expected media by mid")
+	}
+	if m := s.MediaByMid("", 1); m != s.Media[1] {
+		t.Error("expected media by index")
+	}
+	if m := s.MediaByMid("x", 0); m != nil {
+		t.Error("unexpected media for unknown mid")
+	}
+	s.Media[0].Attributes = nil
+	if m := s.MediaByMid("x", 0); m != s.Media[0] {
+		t.Error("expected media by index without mid")
+	}
+}
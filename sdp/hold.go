@@ -0,0 +1,117 @@
+package sdp
+
+// Hold puts the media on hold as described in RFC 3264 section 8.4.
+// It sets sendrecv direction to sendonly and recvonly direction to inactive.
+func (m *Media) Hold() {
+	m.Mode = holdMode(m.Mode)
+}
+
+// Resume takes the media off hold.
+// It sets sendonly direction to sendrecv and inactive direction to recvonly.
+func (m *Media) Resume() {
+	m.Mode = resumeMode(m.Mode)
+}
+
+// Disable rejects or removes the media stream by setting its port to zero
+// as described in RFC 3264 section 8.2.
+func (m *Media) Disable() {
+	m.Port, m.PortNum = 0, 0
+}
+
+// Disabled reports whether the media stream is rejected or removed.
+func (m *Media) Disabled() bool {
+	return m.Port == 0
+}
+
+// Hold puts all enabled media streams on hold.
+// If legacy is set, IP4 connection addresses are also set to 0.0.0.0 for RFC 2543 peers,
+// IP6 connections are left intact as RFC 2543 defines no IPv6 hold address.
+func (s *Session) Hold(legacy bool) {
+	if s.Mode != "" {
+		s.Mode = holdMode(s.Mode)
+	}
+	for _, m := range s.Media {
+		if m.Disabled() {
+			continue
+		}
+		m.Mode = holdMode(s.EffectiveMode(m))
+		if legacy {
+			for _, c := range m.Connection {
+				holdConnection(c)
+			}
+		}
+	}
+	if legacy && s.Connection != nil {
+		holdConnection(s.Connection)
+	}
+}
+
+// Resume takes all enabled media streams off hold.
+// Connection addresses set by legacy hold must be restored by the caller.
+func (s *Session) Resume() {
+	if s.Mode != "" {
+		s.Mode = resumeMode(s.Mode)
+	}
+	for _, m := range s.Media {
+		if !m.Disabled() {
+			m.Mode = resumeMode(s.EffectiveMode(m))
+		}
+	}
+}
+
+// MediaOnHold reports whether the received media description puts the local side on hold.
+// The media is on hold if its direction is sendonly or inactive, or its connection address
+// is IP4 0.0.0.0 as described in RFC 2543 unless the media uses ICE.
+func (s *Session) MediaOnHold(m *Media) bool {
+	if m.Disabled() {
+		return false
+	}
+	switch s.EffectiveMode(m) {
+	case SendOnly, Inactive:
+		return true
+	}
+	if m.Attributes.Has("ice-ufrag") || s.Attributes.Has("ice-ufrag") {
+		return false
+	}
+	c := s.EffectiveConnection(m)
+	return c != nil && c.Type == TypeIPv4 && c.Address == holdAddr
+}
+
+// OnHold reports whether the received session description puts all enabled media streams on hold.
+func (s *Session) OnHold() bool {
+	n := 0
+	for _, m := range s.Media {
+		if m.Disabled() {
+			continue
+		}
+		if !s.MediaOnHold(m) {
+			return false
+		}
+		n++
+	}
+	return n > 0
+}
+
+const holdAddr = "0.0.0.0"
+
+func holdConnection(c *Connection) {
+	if c.Type == TypeIPv4 {
+		c.Address = holdAddr
+	}
+}
+
+func holdMode(mode string) string {
+	switch mode {
+	case RecvOnly, Inactive:
+		return Inactive
+	}
+	return SendOnly
+}
+
+func resumeMode(mode string) string {
+	switch mode {
+	case RecvOnly, Inactive:
+		return RecvOnly
+	}
+	return SendRecv
+}
@@ -0,0 +1,73 @@
+package sdp
+
+import "testing"
+
+func TestHold(t *testing.T) {
+	m := &Media{Type: "audio", Port: 5004}
+	for _, it := range []struct{ mode, hold, resume string }{
+		{"", SendOnly, SendRecv},
+		{SendRecv, SendOnly, SendRecv},
+		{SendOnly, SendOnly, SendRecv},
+		{RecvOnly, Inactive, RecvOnly},
+		{Inactive, Inactive, RecvOnly},
+	} {
+		m.Mode = it.mode
+		if m.Hold(); m.Mode != it.hold {
+			t.Errorf("hold %q: expected %q, got %q", it.mode, it.hold, m.Mode)
+		}
+		if m.Resume(); m.Mode != it.resume {
+			t.Errorf("resume %q: expected %q, got %q", it.mode, it.resume, m.Mode)
+		}
+	}
+	if m.Disable(); !m.Disabled() {
+		t.Error("expected disabled media")
+	}
+}
+
+func TestSessionHold(t *testing.T) {
+	s, err := ParseString("v=0\r\n" +
+		"o=- 1 1 IN IP4 192.0.2.1\r\n" +
+		"s=-\r\n" +
+		"c=IN IP4 192.0.2.1\r\n" +
+		"t=0 0\r\n" +
+		"a=recvonly\r\n" +
+		"m=audio 5004 RTP/AVP 0\r\n" +
+		"a=sendrecv\r\n" +
+		"m=video 5006 RTP/AVP 96\r\n" +
+		"c=IN IP6 2001:db8::1\r\n" +
+		"m=video 0 RTP/AVP 96\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.OnHold() {
+		t.Fatal("unexpected hold")
+	}
+	s.Hold(true)
+	if s.Mode != Inactive || s.Media[0].Mode != SendOnly || s.Media[1].Mode != Inactive || s.Media[2].Mode != "" {
+		t.Errorf("bad modes after hold: %s %s %s %s", s.Mode, s.Media[0].Mode, s.Media[1].Mode, s.Media[2].Mode)
+	}
+	if s.Connection.Address != "0.0.0.0" || s.Media[1].Connection[0].Address != "2001:db8::1" {
+		t.Errorf("bad connections after legacy hold: %s %s", s.Connection.Address, s.Media[1].Connection[0].Address)
+	}
+	if !s.OnHold() {
+		t.Error("expected hold")
+	}
+	r, err := ParseString(s.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.OnHold() {
+		t.Error("expected hold after round trip")
+	}
+	s.Resume()
+	if s.Mode != RecvOnly || s.Media[0].Mode != SendRecv || s.Media[1].Mode != RecvOnly {
+		t.Errorf("bad modes after resume: %s %s %s", s.Mode, s.Media[0].Mode, s.Media[1].Mode)
+	}
+	if !s.MediaOnHold(s.Media[0]) || s.MediaOnHold(s.Media[1]) || s.MediaOnHold(s.Media[2]) {
+		t.Error("bad media hold state after resume with legacy address")
+	}
+	s.Media[0].Attributes = Attributes{NewAttr("ice-ufrag", "abc")}
+	if s.MediaOnHold(s.Media[0]) {
+		t.Error("legacy hold address must be ignored with ICE")
+	}
+}
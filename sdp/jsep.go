@@ -0,0 +1,106 @@
+package sdp
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Session description types as defined in JSEP (RFC 8829).
+const (
+	Offer    = "offer"
+	PrAnswer = "pranswer"
+	Answer   = "answer"
+	Rollback = "rollback"
+)
+
+// SessionDescription represents a WebRTC RTCSessionDescription exchanged by browsers,
+// e.g. {"type":"offer","sdp":"v=0\r\n..."}.
+type SessionDescription struct {
+	Type string `json:"type"` // Offer, PrAnswer, Answer or Rollback
+	SDP  string `json:"sdp"`  // Encoded session description, empty for Rollback
+}
+
+// NewSessionDescription returns a description of the type containing the encoded session.
+// The session must be nil for Rollback.
+func NewSessionDescription(typ string, s *Session) (*SessionDescription, error) {
+	d := &SessionDescription{Type: typ}
+	if s != nil {
+		e := NewEncoder(nil)
+		if err := e.Encode(s); err != nil {
+			return nil, err
+		}
+		d.SDP = e.String()
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Session returns the decoded session description.
+// It returns nil for Rollback.
+func (d *SessionDescription) Session() (*Session, error) {
+	if err := checkDescriptionType(d.Type); err != nil {
+		return nil, err
+	}
+	if d.Type == Rollback {
+		if d.SDP != "" {
+			return nil, errRollbackSDP
+		}
+		return nil, nil
+	}
+	s, err := ParseString(d.SDP)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkDescription(d.Type, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate checks that the description type is known and consistent with the contents.
+// Offers must use "actpass" DTLS setup role, answers must use "active" or "passive" role.
+func (d *SessionDescription) Validate() error {
+	_, err := d.Session()
+	return err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *SessionDescription) UnmarshalJSON(b []byte) error {
+	type description SessionDescription
+	if err := json.Unmarshal(b, (*description)(d)); err != nil {
+		return err
+	}
+	return checkDescriptionType(d.Type)
+}
+
+func checkDescriptionType(typ string) error {
+	switch typ {
+	case Offer, PrAnswer, Answer, Rollback:
+		return nil
+	}
+	return errDescriptionType
+}
+
+func checkDescription(typ string, s *Session) error {
+	setup := []string{s.Attributes.Get("setup")}
+	for _, m := range s.Media {
+		setup = append(setup, m.Attributes.Get("setup"))
+	}
+	for _, it := range setup {
+		switch {
+		case it == "":
+		case typ == Offer && it != "actpass":
+			return errOfferSetup
+		case typ != Offer && it != "active" && it != "passive":
+			return errAnswerSetup
+		}
+	}
+	return nil
+}
+
+var errDescriptionType = errors.New("sdp: unknown session description type")
+var errRollbackSDP = errors.New("sdp: rollback must not contain session description")
+var errOfferSetup = errors.New("sdp: offer must use actpass setup role")
+var errAnswerSetup = errors.New("sdp: answer must use active or passive setup role")
@@ -0,0 +1,110 @@
+package sdp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSessionDescription(t *testing.T) {
+	s := testVectors[1].Session
+	d, err := NewSessionDescription(Offer, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d = new(SessionDescription)
+	if err = json.Unmarshal(b, d); err != nil {
+		t.Fatal(err)
+	}
+	sess, err := d.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	(&T{t}).AssertAny("session", sess, s)
+
+	if err = json.Unmarshal([]byte(`{"type":"bogus","sdp":""}`), d); err == nil {
+		t.Error("expected unknown type error")
+	}
+	if _, err = NewSessionDescription(Rollback, nil); err != nil {
+		t.Error(err)
+	}
+	if _, err = NewSessionDescription(Rollback, s); err == nil {
+		t.Error("expected rollback error")
+	}
+	sess.Media[0].Attributes = Attributes{NewAttr("setup", "actpass")}
+	if _, err = NewSessionDescription(Answer, sess); err == nil {
+		t.Error("expected answer setup role error")
+	}
+	if _, err = NewSessionDescription(Offer, sess); err != nil {
+		t.Error(err)
+	}
+	sess.Name = "x\r\nm=video"
+	if _, err = NewSessionDescription(Offer, sess); err == nil || err.Error() != "sdp: invalid character in s=" {
+		t.Errorf("expected encode error, got: %v", err)
+	}
+}
+
+func TestSignaling(t *testing.T) {
+	offer := &Session{
+		Origin: &Origin{},
+		Mode:   SendOnly,
+		Media: []*Media{
+			{Type: "audio", Port: 9, Proto: "RTP/AVP", Format: []*Format{
+				{Payload: 96, Name: "opus", ClockRate: 48000, Channels: 2},
+				{Payload: 0, Name: "PCMU", ClockRate: 8000},
+			}},
+			{Type: "video", Port: 9, Proto: "RTP/AVP", Format: []*Format{{Payload: 97, Name: "VP8", ClockRate: 90000}}},
+		},
+	}
+	answer := &Session{
+		Origin: &Origin{},
+		Media: []*Media{
+			{Type: "audio", Port: 9, Proto: "RTP/AVP", Mode: RecvOnly, Format: []*Format{{Payload: 0, Name: "PCMU", ClockRate: 8000, Channels: 1}}},
+			{Type: "video", Port: 0, Proto: "RTP/AVP", Format: []*Format{{Payload: 97, Name: "VP8", ClockRate: 90000}}},
+		},
+	}
+	s := new(Signaling)
+	if err := s.SetRemote(Answer, answer); err == nil {
+		t.Fatal("expected invalid state error")
+	}
+	if err := s.SetLocal(Offer, offer); err != nil {
+		t.Fatal(err)
+	}
+	if s.State() != HaveLocalOffer || s.PendingLocal() != offer {
+		t.Fatalf("bad state: %s", s.State())
+	}
+	if err := s.SetLocal(Rollback, nil); err != nil {
+		t.Fatal(err)
+	}
+	if s.State() != Stable || s.Local() != nil {
+		t.Fatalf("bad state after rollback: %s", s.State())
+	}
+	if err := s.SetLocal(Offer, offer); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetRemote(PrAnswer, answer); err != nil {
+		t.Fatal(err)
+	}
+	if s.State() != HaveRemotePrAnswer || len(s.Negotiated()) != 2 {
+		t.Fatalf("bad state: %s", s.State())
+	}
+	if err := s.SetRemote(Answer, answer); err != nil {
+		t.Fatal(err)
+	}
+	if s.State() != Stable || s.CurrentLocal() != offer || s.CurrentRemote() != answer || s.PendingLocal() != nil {
+		t.Fatalf("bad state: %s", s.State())
+	}
+	media := s.Negotiated()
+	if len(media) != 2 || media[0].Mode != SendOnly || len(media[0].Format) != 1 || media[0].Format[0].Name != "PCMU" {
+		t.Fatalf("bad negotiated audio: %s", dump(media))
+	}
+	if !media[1].Rejected || media[1].Mode != Inactive {
+		t.Fatalf("bad negotiated video: %s", dump(media[1]))
+	}
+	if err := s.SetRemote(Rollback, nil); err == nil {
+		t.Fatal("expected invalid state error")
+	}
+}
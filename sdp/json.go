@@ -0,0 +1,203 @@
+package sdp
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// UnmarshalJSON implements json.Unmarshaler. It reports an error for unknown modes.
+//
+// JSON encoding of a session description uses the following conventions:
+//
+//   - Field names are lower camel case, optional fields are omitted when empty.
+//   - Times are encoded as NTP seconds ("start", "stop", "time") along with the
+//     RFC 3339 UTC representation ("startUTC", "stopUTC", "timeUTC"). NTP seconds take
+//     precedence when decoding, zero means an unbounded time.
+//   - Durations are encoded as SDP typed-time strings, e.g. "7d", "25h", "-1h" or "0".
+//   - Modes are one of "sendrecv", "sendonly", "recvonly" or "inactive".
+//
+// For example, "t=3034423619 3042462419" is encoded as
+//
+//	{"start":3034423619,"stop":3042462419,"startUTC":"1996-02-27T15:26:59Z","stopUTC":"1996-05-30T16:26:59Z"}
+func (s *Session) UnmarshalJSON(b []byte) error {
+	type session Session
+	if err := json.Unmarshal(b, (*session)(s)); err != nil {
+		return err
+	}
+	return checkMode(s.Mode)
+}
+
+// mediaJSON is Media with field names of the JSON encoding.
+type mediaJSON struct {
+	Type        string        `json:"type"`
+	Port        int           `json:"port"`
+	PortNum     int           `json:"portNum,omitempty"`
+	Proto       string        `json:"proto"`
+	Information string        `json:"information,omitempty"`
+	Connection  []*Connection `json:"connection,omitempty"`
+	Bandwidth   []*Bandwidth  `json:"bandwidth,omitempty"`
+	Key         []*Key        `json:"key,omitempty"`
+	Attributes  `json:"attributes,omitempty"`
+	Mode        string    `json:"mode,omitempty"`
+	Format      []*Format `json:"format,omitempty"`
+	FormatDescr string    `json:"formatDescr,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m *Media) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*mediaJSON)(m))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Media) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, (*mediaJSON)(m)); err != nil {
+		return err
+	}
+	return checkMode(m.Mode)
+}
+
+type timingJSON struct {
+	Start    int64  `json:"start"`
+	Stop     int64  `json:"stop"`
+	StartUTC string `json:"startUTC,omitempty"`
+	StopUTC  string `json:"stopUTC,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t *Timing) MarshalJSON() ([]byte, error) {
+	v := &timingJSON{}
+	v.Start, v.StartUTC = ntpTime(t.Start)
+	v.Stop, v.StopUTC = ntpTime(t.Stop)
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Timing) UnmarshalJSON(b []byte) error {
+	v := &timingJSON{}
+	err := json.Unmarshal(b, v)
+	if err != nil {
+		return err
+	}
+	if t.Start, err = parseNTPTime(v.Start, v.StartUTC); err != nil {
+		return err
+	}
+	t.Stop, err = parseNTPTime(v.Stop, v.StopUTC)
+	return err
+}
+
+type timeZoneJSON struct {
+	Time    int64  `json:"time"`
+	TimeUTC string `json:"timeUTC,omitempty"`
+	Offset  string `json:"offset"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (z *TimeZone) MarshalJSON() ([]byte, error) {
+	v := &timeZoneJSON{Offset: durationString(z.Offset)}
+	v.Time, v.TimeUTC = ntpTime(z.Time)
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (z *TimeZone) UnmarshalJSON(b []byte) error {
+	v := &timeZoneJSON{}
+	err := json.Unmarshal(b, v)
+	if err != nil {
+		return err
+	}
+	if z.Time, err = parseNTPTime(v.Time, v.TimeUTC); err != nil {
+		return err
+	}
+	z.Offset, err = parseDuration(v.Offset)
+	return err
+}
+
+type repeatJSON struct {
+	Interval string   `json:"interval"`
+	Duration string   `json:"duration"`
+	Offsets  []string `json:"offsets,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *Repeat) MarshalJSON() ([]byte, error) {
+	v := &repeatJSON{
+		Interval: durationString(r.Interval),
+		Duration: durationString(r.Duration),
+	}
+	for _, it := range r.Offsets {
+		v.Offsets = append(v.Offsets, durationString(it))
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Repeat) UnmarshalJSON(b []byte) error {
+	v := &repeatJSON{}
+	err := json.Unmarshal(b, v)
+	if err != nil {
+		return err
+	}
+	if r.Interval, err = parseDuration(v.Interval); err != nil {
+		return err
+	}
+	if r.Duration, err = parseDuration(v.Duration); err != nil {
+		return err
+	}
+	r.Offsets = nil
+	for _, it := range v.Offsets {
+		d, err := parseDuration(it)
+		if err != nil {
+			return err
+		}
+		r.Offsets = append(r.Offsets, d)
+	}
+	return nil
+}
+
+func ntpTime(t time.Time) (int64, string) {
+	if t.IsZero() {
+		return 0, ""
+	}
+	return int64(t.Sub(epoch).Seconds()), t.UTC().Format(time.RFC3339)
+}
+
+func parseNTPTime(sec int64, utc string) (time.Time, error) {
+	switch {
+	case sec != 0:
+		return epoch.Add(time.Second * time.Duration(sec)), nil
+	case utc != "":
+		t, err := time.Parse(time.RFC3339, utc)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.UTC(), nil
+	}
+	return time.Time{}, nil
+}
+
+func durationString(d time.Duration) string {
+	return string(writer(nil).duration(d))
+}
+
+func parseDuration(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, errJSONDuration
+	}
+	d, err := new(Decoder).duration(v)
+	if err != nil {
+		return 0, errJSONDuration
+	}
+	return d, nil
+}
+
+func checkMode(mode string) error {
+	switch mode {
+	case "", SendRecv, SendOnly, RecvOnly, Inactive:
+		return nil
+	}
+	return errJSONMode
+}
+
+var errJSONDuration = errors.New("sdp: invalid json duration")
+var errJSONMode = errors.New("sdp: invalid json mode")
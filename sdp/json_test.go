@@ -0,0 +1,54 @@
+package sdp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	for _, v := range testVectors {
+		v := v
+		t.Run(v.Name, func(inner *testing.T) {
+			t := &T{inner}
+			b, err := json.Marshal(v.Session)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sess := new(Session)
+			if err = json.Unmarshal(b, sess); err != nil {
+				t.Fatal(err)
+			}
+			t.AssertAny("decoded", sess, v.Session)
+			t.Assert("encoded", sess.String(), v.Session.String())
+		})
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	b, err := json.Marshal(testVectors[0].Session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, it := range []string{
+		`"timing":{"start":3034423619,"stop":3042462419,"startUTC":"1996-02-27T15:26:59Z","stopUTC":"1996-05-30T16:26:59Z"}`,
+		`"repeat":[{"interval":"7d","duration":"1h","offsets":["0","25h"]}]`,
+		`{"time":3034423619,"timeUTC":"1996-02-27T15:26:59Z","offset":"-1h"}`,
+		`"mode":"recvonly"`,
+		`"connection":{"network":"IN","type":"IP4","address":"224.2.17.12","ttl":127}`,
+	} {
+		if !strings.Contains(string(b), it) {
+			t.Errorf("expected %s in %s", it, b)
+		}
+	}
+	sess := new(Session)
+	if err = json.Unmarshal([]byte(`{"timing":{"start":0,"stop":0,"startUTC":"1996-02-27T15:26:59Z"},"media":[{"mode":"sendrecv"}]}`), sess); err != nil {
+		t.Fatal(err)
+	}
+	if sess.Timing.Start != parseTime("1996-02-27 15:26:59 +0000 UTC") {
+		t.Errorf("bad start time: %v", sess.Timing.Start)
+	}
+	if err = json.Unmarshal([]byte(`{"media":[{"mode":"sendrecv2"}]}`), sess); err == nil {
+		t.Error("expected invalid mode error")
+	}
+}
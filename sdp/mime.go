@@ -0,0 +1,161 @@
+package sdp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// ParseMessage reads all session descriptions from the body of a SIP message.
+// The body is located using the Content-Type and Content-Length headers, including their compact forms.
+// It returns no sessions and no error if the message has no "application/sdp" body parts.
+func ParseMessage(msg []byte) ([]*Session, error) {
+	i, n := bytes.Index(msg, []byte("\r\n\r\n")), 4
+	if j := bytes.Index(msg, []byte("\n\n")); j >= 0 && (i < 0 || j < i) {
+		i, n = j, 2
+	}
+	if i < 0 {
+		return nil, errMessage
+	}
+	r := textproto.NewReader(bufio.NewReader(bytes.NewReader(msg[:i+n])))
+	if _, err := r.ReadLine(); err != nil {
+		return nil, errMessage
+	}
+	h, err := r.ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	body := msg[i+n:]
+	if v := headerValue(h, "Content-Length", "L"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 0 {
+			return nil, errMessage
+		}
+		if size > len(body) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		body = body[:size]
+	}
+	typ := headerValue(h, "Content-Type", "C")
+	if typ == "" || len(body) == 0 {
+		return nil, nil
+	}
+	return ParseBody(typ, body)
+}
+
+// ParseBody reads all session descriptions from the message body of the given content type.
+// Parts of "multipart" bodies are searched recursively.
+// It returns no sessions and no error if the body has no "application/sdp" parts.
+func ParseBody(contentType string, body []byte) ([]*Session, error) {
+	return parseBody(nil, contentType, body)
+}
+
+func parseBody(sess []*Session, contentType string, body []byte) ([]*Session, error) {
+	typ, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, err
+	}
+	if typ == ContentType {
+		s, err := Parse(body)
+		if err != nil {
+			return nil, err
+		}
+		return append(sess, s), nil
+	}
+	if !strings.HasPrefix(typ, "multipart/") {
+		return sess, nil
+	}
+	boundary := params["boundary"]
+	if boundary == "" {
+		return nil, errMessage
+	}
+	r := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			return sess, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		typ := p.Header.Get("Content-Type")
+		if typ == "" {
+			typ = "text/plain"
+		}
+		var b []byte
+		if strings.EqualFold(p.Header.Get("Content-Transfer-Encoding"), "base64") {
+			b, err = ioutil.ReadAll(base64.NewDecoder(base64.StdEncoding, p))
+		} else {
+			b, err = ioutil.ReadAll(p)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if sess, err = parseBody(sess, typ, b); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Part represents a non-SDP body part of a multipart message body, such as ISUP or PIDF.
+type Part struct {
+	ContentType string
+	Header      textproto.MIMEHeader // Additional part headers, e.g. Content-Disposition
+	Body        []byte
+}
+
+// NewMultipart returns a "multipart/mixed" body containing the encoded session description
+// followed by parts, and its content type with boundary parameter.
+func NewMultipart(s *Session, parts ...*Part) (contentType string, body []byte, err error) {
+	e := NewEncoder(nil)
+	if err = e.Encode(s); err != nil {
+		return
+	}
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Type", ContentType)
+	if err = writePart(w, h, e.Bytes()); err != nil {
+		return
+	}
+	for _, p := range parts {
+		h = textproto.MIMEHeader{}
+		for k, v := range p.Header {
+			h[k] = v
+		}
+		h.Set("Content-Type", p.ContentType)
+		if err = writePart(w, h, p.Body); err != nil {
+			return
+		}
+	}
+	if err = w.Close(); err != nil {
+		return
+	}
+	return mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": w.Boundary()}), b.Bytes(), nil
+}
+
+func writePart(w *multipart.Writer, h textproto.MIMEHeader, body []byte) error {
+	pw, err := w.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	_, err = pw.Write(body)
+	return err
+}
+
+func headerValue(h textproto.MIMEHeader, name, compact string) string {
+	if v := h.Get(name); v != "" {
+		return v
+	}
+	return h.Get(compact)
+}
+
+var errMessage = errors.New("sdp: malformed message")
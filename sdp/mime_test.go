@@ -0,0 +1,57 @@
+package sdp
+
+import (
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseMessage(t *testing.T) {
+	sdp := testVectors[1].Data
+	body := "--unique-boundary-1\r\n" +
+		"Content-Type: application/sdp\r\n\r\n" +
+		sdp + "\r\n" +
+		"--unique-boundary-1\r\n" +
+		"Content-Type: application/ISUP;version=nxv3\r\n" +
+		"Content-Disposition: signal;handling=optional\r\n\r\n" +
+		"\x01\x00\x49\x00\x00\x03\x02\x00\x07\r\n" +
+		"--unique-boundary-1--\r\n"
+	msg := "INVITE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP pc33.atlanta.example.com;branch=z9hG4bK776asdhds\r\n" +
+		"c: multipart/mixed;boundary=unique-boundary-1\r\n" +
+		"l: " + strconv.Itoa(len(body)) + "\r\n\r\n" + body + "garbage"
+	sess, err := ParseMessage([]byte(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sess) != 1 {
+		t.Fatalf("bad number of sessions: %d", len(sess))
+	}
+	(&T{t}).AssertAny("session", sess[0], testVectors[1].Session)
+}
+
+func TestNewMultipart(t *testing.T) {
+	s := testVectors[0].Session
+	typ, body, err := NewMultipart(s, &Part{
+		ContentType: "application/pidf+xml",
+		Header:      textproto.MIMEHeader{"Content-Id": {"<alice@example.com>"}},
+		Body:        []byte("<presence/>"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(typ, "multipart/mixed; boundary=") {
+		t.Fatalf("bad content type: %s", typ)
+	}
+	sess, err := ParseBody(typ, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sess) != 1 || sess[0].String() != s.String() {
+		t.Fatalf("bad sessions: %v", sess)
+	}
+	if _, _, err = NewMultipart(&Session{Name: "x\r\nm=video"}); err == nil || err.Error() != "sdp: invalid character in s=" {
+		t.Fatalf("expected encode error, got: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package sdp
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+)
+
+// Addrs returns IP addresses of the connection expanding the multicast address range
+// as described in RFC 4566 section 5.7: "224.2.1.1/127/3" is 224.2.1.1, 224.2.1.2 and 224.2.1.3.
+func (c *Connection) Addrs() ([]netip.Addr, error) {
+	addr := c.Addr()
+	if !addr.IsValid() {
+		return nil, errConnectionAddr
+	}
+	n := count(c.AddressNum)
+	if n > 1 && !addr.IsMulticast() {
+		return nil, errAddressNum
+	}
+	r := make([]netip.Addr, 0, n)
+	for i := 0; i < n; i++ {
+		if !addr.IsValid() || i > 0 && !addr.IsMulticast() {
+			return nil, errAddressNum
+		}
+		r = append(r, addr)
+		addr = addr.Next()
+	}
+	return r, nil
+}
+
+// Validate checks the connection address, its type, TTL and number of addresses.
+// TTL is required for IPv4 multicast addresses and must not be set for IPv4 unicast and IPv6 addresses.
+// Multiple addresses are allowed for multicast addresses only.
+func (c *Connection) Validate() error {
+	if err := checkAddr(c.Network, c.Type, c.Address); err != nil {
+		return err
+	}
+	addr := c.Addr()
+	if !addr.IsValid() {
+		if c.TTL != 0 || c.AddressNum > 1 {
+			return errConnectionAddr
+		}
+		return nil
+	}
+	switch {
+	case c.TTL < 0 || c.TTL > 255:
+		return errTTL
+	case addr.Is4() && addr.IsMulticast():
+		if c.TTL == 0 {
+			return errTTL
+		}
+	case c.TTL != 0:
+		return errTTL
+	case c.AddressNum > 1 && !addr.IsMulticast():
+		return errAddressNum
+	}
+	return nil
+}
+
+// RTPAddrs returns transport addresses of the media for layered encodings as described
+// in RFC 4566 section 5.14. Addresses of connection data or the session-level connection data
+// are paired with ports starting from the media port. Ports are incremented by 2 for RTP
+// profiles and by 1 otherwise. If both several addresses and ports are specified,
+// their numbers must match.
+func (s *Session) RTPAddrs(m *Media) ([]netip.AddrPort, error) {
+	conn := m.Connection
+	if len(conn) == 0 && s.Connection != nil {
+		conn = []*Connection{s.Connection}
+	}
+	if len(conn) == 0 {
+		return nil, errConnectionAddr
+	}
+	var addrs []netip.Addr
+	for _, c := range conn {
+		v, err := c.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, v...)
+	}
+	ports, step := count(m.PortNum), 1
+	if strings.Contains(m.Proto, "RTP/") {
+		step = 2
+	}
+	if m.Port+(ports-1)*step > 0xffff {
+		return nil, errPortNum
+	}
+	n := len(addrs)
+	switch {
+	case n > 1 && ports > 1 && n != ports:
+		return nil, errPortNum
+	case ports > n:
+		n = ports
+	}
+	r := make([]netip.AddrPort, n)
+	for i := range r {
+		addr, port := addrs[0], m.Port
+		if len(addrs) > 1 {
+			addr = addrs[i]
+		}
+		if ports > 1 {
+			port += i * step
+		}
+		r[i] = netip.AddrPortFrom(addr, uint16(port))
+	}
+	return r, nil
+}
+
+var (
+	errConnectionAddr = errors.New("sdp: connection address is not an IP address")
+	errAddressNum     = errors.New("sdp: invalid number of addresses")
+	errTTL            = errors.New("sdp: invalid TTL")
+	errPortNum        = errors.New("sdp: invalid number of ports")
+)
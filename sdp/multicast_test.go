@@ -0,0 +1,51 @@
+package sdp
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMulticast(t *testing.T) {
+	for _, it := range []struct {
+		conn, media, addrs string
+	}{
+		{"c=IN IP4 224.2.1.1/127/2", "m=video 49170/2 RTP/AVP 31", "[224.2.1.1:49170 224.2.1.2:49172]"},
+		{"c=IN IP4 224.2.1.1/127", "m=video 49170/2 RTP/AVP 31", "[224.2.1.1:49170 224.2.1.1:49172]"},
+		{"c=IN IP4 224.2.1.1/127/3", "m=video 49170 RTP/AVP 31", "[224.2.1.1:49170 224.2.1.2:49170 224.2.1.3:49170]"},
+		{"c=IN IP6 FF15::101/3", "m=application 5000/3 udp wb", "[[ff15::101]:5000 [ff15::102]:5001 [ff15::103]:5002]"},
+		{"c=IN IP4 192.0.2.1", "m=audio 5004 RTP/AVP 0", "[192.0.2.1:5004]"},
+		{"c=IN IP4 224.2.1.1/127", "m=video 49170/2 UDP/TLS/RTP/SAVPF 96", "[224.2.1.1:49170 224.2.1.1:49172]"},
+		{"c=IN IP4 224.2.1.1/127/3", "m=video 49170/2 RTP/AVP 31", "sdp: invalid number of ports"},
+		{"c=IN IP4 192.0.2.1/127/3", "m=video 49170 RTP/AVP 31", "sdp: invalid number of addresses"},
+		{"c=IN IP4 host.example.com", "m=video 49170 RTP/AVP 31", "sdp: connection address is not an IP address"},
+	} {
+		s, err := ParseString("v=0\r\no=- 1 1 IN IP4 192.0.2.1\r\ns=-\r\n" + it.conn + "\r\nt=0 0\r\n" + it.media + "\r\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrs, err := s.RTPAddrs(s.Media[0])
+		v := fmt.Sprint(addrs)
+		if err != nil {
+			v = err.Error()
+		}
+		if v != it.addrs {
+			t.Errorf("bad addresses for %q: %s, expected %s", it.conn, v, it.addrs)
+		}
+	}
+	for _, it := range []struct {
+		conn *Connection
+		ok   bool
+	}{
+		{&Connection{Network: "IN", Type: "IP4", Address: "224.2.1.1", TTL: 127, AddressNum: 3}, true},
+		{&Connection{Network: "IN", Type: "IP4", Address: "224.2.1.1"}, false},
+		{&Connection{Network: "IN", Type: "IP4", Address: "192.0.2.1", TTL: 127}, false},
+		{&Connection{Network: "IN", Type: "IP4", Address: "192.0.2.1", AddressNum: 2}, false},
+		{&Connection{Network: "IN", Type: "IP6", Address: "ff15::101", AddressNum: 3}, true},
+		{&Connection{Network: "IN", Type: "IP6", Address: "ff15::101", TTL: 1}, false},
+		{&Connection{Network: "IN", Type: "IP4", Address: "host.example.com"}, true},
+	} {
+		if err := it.conn.Validate(); (err == nil) != it.ok {
+			t.Errorf("bad validation of %+v: %v", it.conn, err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package sdp
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PreferFormat reorders media formats by the list of encoding names in order of preference.
+// Unlisted formats keep their relative order after listed ones.
+// Dependent formats such as RTX follow the format they depend on.
+func (m *Media) PreferFormat(name ...string) {
+	rank := make(map[uint8]int)
+	for _, f := range m.Format {
+		rank[f.Payload] = len(name)
+		for i, v := range name {
+			if strings.EqualFold(f.Name, v) {
+				rank[f.Payload] = i
+				break
+			}
+		}
+	}
+	for _, f := range m.Format {
+		if dep := f.dependsOn(); len(dep) > 0 {
+			if r, ok := rank[dep[0]]; ok {
+				rank[f.Payload] = r
+			}
+		}
+	}
+	sort.SliceStable(m.Format, func(i, j int) bool {
+		return rank[m.Format[i].Payload] < rank[m.Format[j].Payload]
+	})
+}
+
+// RemoveFormat removes media formats by encoding names along with their "rtcp-fb" and "fmtp"
+// attributes and dependent formats such as RTX or RED referring to removed payload types.
+// The media stream is disabled if no formats remain, see FilterFormat.
+func (m *Media) RemoveFormat(name ...string) {
+	m.FilterFormat(func(f *Format) bool {
+		for _, v := range name {
+			if strings.EqualFold(f.Name, v) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// FilterFormat keeps only media formats for which keep returns true
+// and formats depending on them. If no formats remain, the media stream is disabled
+// since the format list must not be empty, as described in RFC 3264 section 6.
+// The payload type of the first format is kept as a placeholder without encoding name,
+// parameters and feedback, so the removed codec is no longer described.
+func (m *Media) FilterFormat(keep func(f *Format) bool) {
+	removed := make(map[uint8]bool)
+	for _, f := range m.Format {
+		if !keep(f) {
+			removed[f.Payload] = true
+		}
+	}
+	for n := -1; n != len(removed); {
+		n = len(removed)
+		for _, f := range m.Format {
+			for _, pt := range f.dependsOn() {
+				if removed[pt] {
+					removed[f.Payload] = true
+					break
+				}
+			}
+		}
+	}
+	if len(removed) > 0 && len(removed) == len(m.Format) {
+		m.Disable()
+		f := &Format{Payload: m.Format[0].Payload}
+		for i := range m.Format {
+			m.Format[i] = nil
+		}
+		m.Format = append(m.Format[:0], f)
+		return
+	}
+	r := m.Format[:0]
+	for _, f := range m.Format {
+		if !removed[f.Payload] {
+			r = append(r, f)
+		}
+	}
+	for i := len(r); i < len(m.Format); i++ {
+		m.Format[i] = nil
+	}
+	m.Format = r
+}
+
+// SetBandwidth sets the bandwidth value of the type replacing existing ones.
+func (m *Media) SetBandwidth(typ string, value int) {
+	m.Bandwidth = setBandwidth(m.Bandwidth, typ, value)
+}
+
+// SetMaxBitrate limits the media bitrate in bits per second.
+// It sets "b=TIAS" bandwidth, "b=AS" bandwidth including IPv4 packet overhead for the media packet time
+// and codec-specific limits such as Opus "maxaveragebitrate".
+func (m *Media) SetMaxBitrate(bps int) {
+	m.SetBandwidth(BandwidthAS, ASFromTIAS(bps, m.PacketTime(), OverheadIPv4))
+	m.SetBandwidth(BandwidthTIAS, bps)
+	for _, f := range m.Format {
+		if strings.EqualFold(f.Name, "opus") {
+			v := bps
+			if v < 6000 {
+				v = 6000
+			} else if v > 510000 {
+				v = 510000
+			}
+			f.SetParam("maxaveragebitrate", strconv.Itoa(v))
+		}
+	}
+}
+
+// Param returns the format parameter value by name from "fmtp" attributes.
+func (f *Format) Param(name string) string {
+	for _, it := range f.Params {
+		for _, p := range strings.Split(it, ";") {
+			k, v := splitParam(p)
+			if strings.EqualFold(k, name) {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// SetParam sets the format parameter value in the first "fmtp" attribute.
+func (f *Format) SetParam(name, value string) {
+	if len(f.Params) == 0 {
+		f.Params = []string{name + "=" + value}
+		return
+	}
+	p := strings.Split(f.Params[0], ";")
+	for i, it := range p {
+		if k, _ := splitParam(it); strings.EqualFold(k, name) {
+			p[i] = name + "=" + value
+			f.Params[0] = strings.Join(p, ";")
+			return
+		}
+	}
+	f.Params[0] = strings.Join(append(p, name+"="+value), ";")
+}
+
+// dependsOn returns payload types the format depends on: "apt" parameter of RTX and FEC
+// formats, or redundant payload types of RED ("96/96").
+func (f *Format) dependsOn() []uint8 {
+	if v := f.Param("apt"); v != "" {
+		if pt, err := strconv.ParseUint(v, 10, 8); err == nil {
+			return []uint8{uint8(pt)}
+		}
+		return nil
+	}
+	if !strings.EqualFold(f.Name, "red") || len(f.Params) == 0 {
+		return nil
+	}
+	var r []uint8
+	for _, it := range strings.Split(f.Params[0], "/") {
+		pt, err := strconv.ParseUint(strings.TrimSpace(it), 10, 8)
+		if err != nil {
+			return nil
+		}
+		r = append(r, uint8(pt))
+	}
+	return r
+}
+
+func splitParam(p string) (string, string) {
+	p = strings.TrimSpace(p)
+	if i := strings.IndexByte(p, '='); i >= 0 {
+		return strings.TrimSpace(p[:i]), strings.TrimSpace(p[i+1:])
+	}
+	return p, ""
+}
+
+func setBandwidth(b []*Bandwidth, typ string, value int) []*Bandwidth {
+	r, found := b[:0], false
+	for _, it := range b {
+		if !strings.EqualFold(it.Type, typ) {
+			r = append(r, it)
+		} else if !found {
+			it.Value, found = value, true
+			r = append(r, it)
+		}
+	}
+	for i := len(r); i < len(b); i++ {
+		b[i] = nil
+	}
+	if !found {
+		r = append(r, &Bandwidth{Type: typ, Value: value})
+	}
+	return r
+}
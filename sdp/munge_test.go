@@ -0,0 +1,58 @@
+package sdp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMunge(t *testing.T) {
+	s, err := ParseString(`v=0
+o=- 0 0 IN IP4 127.0.0.1
+s=-
+t=0 0
+m=video 9 UDP/TLS/RTP/SAVPF 96 97 98 99 100 101
+a=rtpmap:96 VP8/90000
+a=rtcp-fb:96 nack
+a=rtpmap:97 rtx/90000
+a=fmtp:97 apt=96
+a=rtpmap:98 H264/90000
+a=fmtp:98 profile-level-id=42e01f
+a=rtpmap:99 rtx/90000
+a=fmtp:99 apt=98
+a=rtpmap:100 VP9/90000
+a=rtpmap:101 rtx/90000
+a=fmtp:101 apt=100
+m=audio 9 UDP/TLS/RTP/SAVPF 111 63
+a=rtpmap:111 opus/48000/2
+a=fmtp:111 minptime=10;useinbandfec=1
+a=rtpmap:63 red/48000/2
+a=fmtp:63 111/111
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	video, audio := s.Media[0], s.Media[1]
+	video.PreferFormat("VP9")
+	video.RemoveFormat("h264")
+	video.SetMaxBitrate(1500000)
+	if v := formatString(video); v != "100 VP9/90000, 101 rtx/90000, 96 VP8/90000, 97 rtx/90000" {
+		t.Errorf("bad video formats: %s", v)
+	}
+	if v := string(writer(nil).bandwidth(video.Bandwidth[0]).sp().bandwidth(video.Bandwidth[1])); v != "AS:1516 TIAS:1500000" {
+		t.Errorf("bad video bandwidth: %s", v)
+	}
+	audio.SetMaxBitrate(64000)
+	if v := audio.Format[0].Params[0]; v != "minptime=10;useinbandfec=1;maxaveragebitrate=64000" {
+		t.Errorf("bad opus params: %s", v)
+	}
+	audio.RemoveFormat("opus")
+	if !audio.Disabled() || len(audio.Format) != 1 {
+		t.Fatalf("expected disabled audio with placeholder format: %s, port %d", formatString(audio), audio.Port)
+	}
+	if f := audio.Format[0]; f.Payload != 111 || f.Name != "" || len(f.Params) != 0 || len(f.Feedback) != 0 {
+		t.Errorf("placeholder format describes the removed codec: %s", formatString(audio))
+	}
+	if v := string(writer(nil).media(audio, nil)); strings.Contains(v, "opus") || !strings.HasPrefix(v, "\r\nm=audio 0 ") {
+		t.Errorf("bad disabled audio: %q", v)
+	}
+}
@@ -0,0 +1,72 @@
+package sdp
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"strings"
+	"time"
+)
+
+// NewOrigin returns an origin with a random 62-bit session ID and zero session version
+// as recommended by RFC 8829 section 5.2.1.
+func NewOrigin(username, address string) *Origin {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return newOrigin(username, address, int64(binary.BigEndian.Uint64(b[:])>>2), 0)
+}
+
+// NewOriginTime returns an origin with session ID and version set to the NTP timestamp of t
+// as suggested by RFC 4566 section 5.2.
+func NewOriginTime(username, address string, t time.Time) *Origin {
+	v, _ := ntpTime(t)
+	return newOrigin(username, address, v, v)
+}
+
+func newOrigin(username, address string, id, version int64) *Origin {
+	typ := TypeIPv4
+	if strings.IndexByte(address, ':') >= 0 {
+		typ = TypeIPv6
+	}
+	return &Origin{
+		Username:       strdef(username, "-"),
+		SessionID:      id,
+		SessionVersion: version,
+		Network:        NetworkInternet,
+		Type:           typ,
+		Address:        address,
+	}
+}
+
+// VersionTracker maintains the origin of session descriptions sent during a session.
+// The session version is incremented only when the description changes as required by RFC 3264 section 8.
+type VersionTracker struct {
+	origin *Origin
+	last   *Session
+}
+
+// NewVersionTracker returns a tracker for the session with the origin.
+func NewVersionTracker(o *Origin) *VersionTracker {
+	c := *o
+	return &VersionTracker{origin: &c}
+}
+
+// Origin returns the origin of the last sent session description.
+func (t *VersionTracker) Origin() *Origin {
+	c := *t.origin
+	return &c
+}
+
+// Update sets the origin of s before sending. The session version is incremented
+// if s is not semantically equal to the last sent description, ignoring the origin.
+func (t *VersionTracker) Update(s *Session) {
+	o := *t.origin
+	s.Origin = &o
+	if t.last != nil && !s.Equal(t.last) {
+		t.origin.SessionVersion++
+		s.Origin.SessionVersion = t.origin.SessionVersion
+	}
+	t.last = s.Clone()
+	t.last.Origin = t.origin
+}
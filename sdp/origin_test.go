@@ -0,0 +1,30 @@
+package sdp
+
+import "testing"
+
+func TestVersionTracker(t *testing.T) {
+	o := NewOrigin("", "2001:db8::1")
+	if o.SessionID <= 0 || o.SessionID >= 1<<62 || o.Type != TypeIPv6 || o.Username != "-" {
+		t.Fatalf("bad origin: %s", dump(o))
+	}
+	tr := NewVersionTracker(o)
+	s := testVectors[1].Session.Clone()
+	tr.Update(s)
+	if s.Origin.SessionVersion != 0 {
+		t.Fatalf("bad initial version: %d", s.Origin.SessionVersion)
+	}
+	s = s.Clone()
+	tr.Update(s)
+	if s.Origin.SessionVersion != 0 {
+		t.Fatalf("version changed for the same session: %d", s.Origin.SessionVersion)
+	}
+	s.Media[0].Mode = SendOnly
+	tr.Update(s)
+	if s.Origin.SessionVersion != 1 || tr.Origin().SessionVersion != 1 || s.Origin.SessionID != o.SessionID {
+		t.Fatalf("bad origin after change: %s", dump(s.Origin))
+	}
+	o = NewOriginTime("alice", "192.0.2.1", parseTime("1996-02-27 15:26:59 +0000 UTC"))
+	if o.SessionID != 3034423619 || o.SessionVersion != 3034423619 {
+		t.Fatalf("bad ntp origin: %s", dump(o))
+	}
+}
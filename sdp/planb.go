@@ -0,0 +1,284 @@
+package sdp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SplitPlanB converts Plan B audio and video media descriptions carrying several tracks
+// into Unified Plan ones with a media description per track.
+// Tracks are identified by "msid" (or legacy "mslabel" and "label") source attributes,
+// SSRCs of "ssrc-group" attributes follow the track of their group.
+// The first track keeps the original mid, the following tracks get new unique mids
+// which are added to the BUNDLE group next to the original mid.
+func (s *Session) SplitPlanB() {
+	var media []*Media
+	var added []string
+	for _, m := range s.Media {
+		tracks := planBTracks(m)
+		if len(tracks) < 2 {
+			media = append(media, m)
+			continue
+		}
+		mid := m.Attributes.Get("mid")
+		var mids []string
+		for i, t := range tracks {
+			it := m.Clone()
+			it.Attributes = DeleteAttr(it.Attributes, "mid", "msid", "ssrc", "ssrc-group")
+			v := mid
+			if i > 0 || v == "" {
+				v = s.newMid(added)
+				added = append(added, v)
+			}
+			mids = append(mids, v)
+			it.Attributes = append(it.Attributes, NewAttr("mid", v), NewAttr("msid", t.msid))
+			for _, a := range m.Attributes {
+				switch a.Name {
+				case "ssrc-group":
+					p := strings.Fields(a.Value)
+					if len(p) > 1 && t.has(p[1:]...) {
+						it.Attributes = append(it.Attributes, NewAttr(a.Name, a.Value))
+					}
+				case "ssrc":
+					if t.has(ssrcID(a.Value)) {
+						it.Attributes = append(it.Attributes, NewAttr(a.Name, a.Value))
+					}
+				}
+			}
+			media = append(media, it)
+		}
+		if mid != "" {
+			s.replaceBundleMid(mid, mids)
+		}
+	}
+	s.Media = media
+}
+
+// MergePlanB converts Unified Plan media descriptions into Plan B ones by merging
+// all enabled media descriptions of the same type into the first one.
+// Media-level "msid" attributes are moved to "ssrc" attributes, directions are combined,
+// and mids of merged media descriptions are removed from the BUNDLE group.
+func (s *Session) MergePlanB() {
+	var media []*Media
+	base := make(map[string]*Media)
+	for _, m := range s.Media {
+		if m.Disabled() || m.Type != "audio" && m.Type != "video" {
+			media = append(media, m)
+			continue
+		}
+		attrs := ssrcMsid(m)
+		b := base[m.Type]
+		if b == nil {
+			m.Attributes = attrs
+			base[m.Type] = m
+			media = append(media, m)
+			continue
+		}
+		for _, a := range attrs {
+			switch a.Name {
+			case "ssrc", "ssrc-group":
+				b.Attributes = append(b.Attributes, a)
+			}
+		}
+		b.Mode = unionMode(s.EffectiveMode(b), s.EffectiveMode(m))
+		if mid := m.Attributes.Get("mid"); mid != "" {
+			s.replaceBundleMid(mid, nil)
+		}
+	}
+	s.Media = media
+}
+
+type planBTrack struct {
+	msid  string
+	ssrcs []string
+}
+
+func (t *planBTrack) has(ssrc ...string) bool {
+	for _, v := range ssrc {
+		found := false
+		for _, it := range t.ssrcs {
+			if it == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// planBTracks returns tracks of the media description in order of their first SSRC.
+func planBTracks(m *Media) []*planBTrack {
+	var order []string
+	msid := make(map[string]string)
+	label := make(map[string][2]string)
+	for _, a := range m.Attributes {
+		if a.Name != "ssrc" {
+			continue
+		}
+		id := ssrcID(a.Value)
+		if _, ok := label[id]; !ok {
+			order = append(order, id)
+			label[id] = [2]string{}
+		}
+		name, v := ssrcAttr(a.Value)
+		switch name {
+		case "msid":
+			msid[id] = v
+		case "mslabel":
+			l := label[id]
+			l[0] = v
+			label[id] = l
+		case "label":
+			l := label[id]
+			l[1] = v
+			label[id] = l
+		}
+	}
+	for id, l := range label {
+		if msid[id] == "" && l[0] != "" && l[1] != "" {
+			msid[id] = l[0] + " " + l[1]
+		}
+	}
+	for _, a := range m.Attributes {
+		if a.Name != "ssrc-group" {
+			continue
+		}
+		p := strings.Fields(a.Value)
+		if len(p) < 2 {
+			continue
+		}
+		v := ""
+		for _, id := range p[1:] {
+			if v = msid[id]; v != "" {
+				break
+			}
+		}
+		for _, id := range p[1:] {
+			if msid[id] == "" {
+				msid[id] = v
+			}
+		}
+	}
+	var tracks []*planBTrack
+	index := make(map[string]*planBTrack)
+	for _, id := range order {
+		v := msid[id]
+		if v == "" {
+			continue
+		}
+		t := index[v]
+		if t == nil {
+			t = &planBTrack{msid: v}
+			index[v] = t
+			tracks = append(tracks, t)
+		}
+		t.ssrcs = append(t.ssrcs, id)
+	}
+	return tracks
+}
+
+// ssrcMsid returns media attributes with the media-level "msid" moved to its "ssrc" attributes.
+func ssrcMsid(m *Media) Attributes {
+	msid := m.Attributes.Get("msid")
+	attrs := DeleteAttr(m.Attributes.Clone(), "msid")
+	if msid == "" {
+		return attrs
+	}
+	var order []string
+	has := make(map[string]bool)
+	for _, a := range attrs {
+		if a.Name != "ssrc" {
+			continue
+		}
+		id := ssrcID(a.Value)
+		if _, ok := has[id]; !ok {
+			order = append(order, id)
+			has[id] = false
+		}
+		if name, _ := ssrcAttr(a.Value); name == "msid" {
+			has[id] = true
+		}
+	}
+	for _, id := range order {
+		if !has[id] {
+			attrs = append(attrs, NewAttr("ssrc", id+" msid:"+msid))
+		}
+	}
+	return attrs
+}
+
+// ssrcID returns the SSRC of the "ssrc" attribute value.
+func ssrcID(v string) string {
+	if i := strings.IndexByte(v, ' '); i >= 0 {
+		return v[:i]
+	}
+	return v
+}
+
+// ssrcAttr returns the source attribute name and value of the "ssrc" attribute value.
+func ssrcAttr(v string) (string, string) {
+	i := strings.IndexByte(v, ' ')
+	if i < 0 {
+		return "", ""
+	}
+	v = v[i+1:]
+	if i = strings.IndexByte(v, ':'); i >= 0 {
+		return v[:i], v[i+1:]
+	}
+	return v, ""
+}
+
+// newMid returns a numeric mid not used by the session and reserved.
+func (s *Session) newMid(reserved []string) string {
+	used := make(map[string]bool)
+	for _, m := range s.Media {
+		used[m.Attributes.Get("mid")] = true
+	}
+	for _, v := range reserved {
+		used[v] = true
+	}
+	for i := 0; ; i++ {
+		if v := strconv.Itoa(i); !used[v] {
+			return v
+		}
+	}
+}
+
+// replaceBundleMid replaces the mid in BUNDLE groups with mids.
+func (s *Session) replaceBundleMid(mid string, mids []string) {
+	for _, a := range s.Attributes {
+		if a.Name != "group" {
+			continue
+		}
+		p := strings.Fields(a.Value)
+		if len(p) == 0 || p[0] != "BUNDLE" {
+			continue
+		}
+		r := p[:1:1]
+		for _, it := range p[1:] {
+			if it == mid {
+				r = append(r, mids...)
+			} else {
+				r = append(r, it)
+			}
+		}
+		a.Value = strings.Join(r, " ")
+	}
+}
+
+func unionMode(a, b string) string {
+	send := a == SendRecv || a == SendOnly || b == SendRecv || b == SendOnly
+	recv := a == SendRecv || a == RecvOnly || b == SendRecv || b == RecvOnly
+	switch {
+	case send && recv:
+		return SendRecv
+	case send:
+		return SendOnly
+	case recv:
+		return RecvOnly
+	}
+	return Inactive
+}
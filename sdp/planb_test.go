@@ -0,0 +1,64 @@
+package sdp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlanB(t *testing.T) {
+	planB := `v=0
+o=- 0 0 IN IP4 127.0.0.1
+s=-
+t=0 0
+a=group:BUNDLE audio video
+m=audio 9 UDP/TLS/RTP/SAVPF 111
+a=rtpmap:111 opus/48000/2
+a=mid:audio
+a=ssrc:1 msid:stream0 audio0
+m=video 9 UDP/TLS/RTP/SAVPF 96 97
+a=rtpmap:96 VP8/90000
+a=rtpmap:97 rtx/90000
+a=fmtp:97 apt=96
+a=sendrecv
+a=mid:video
+a=ssrc-group:FID 2 3
+a=ssrc:2 cname:a
+a=ssrc:2 msid:stream0 video0
+a=ssrc:3 cname:a
+a=ssrc:4 cname:b
+a=ssrc:4 mslabel:stream1
+a=ssrc:4 label:video1
+`
+	s, err := ParseString(planB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SplitPlanB()
+	if len(s.Media) != 3 {
+		t.Fatalf("bad number of media: %d", len(s.Media))
+	}
+	if v := s.Attributes.Get("group"); v != "BUNDLE audio video 0" {
+		t.Fatalf("bad bundle group: %s", v)
+	}
+	exp := []string{
+		"mid:video msid:stream0 video0 ssrc-group:FID 2 3 ssrc:2 cname:a ssrc:2 msid:stream0 video0 ssrc:3 cname:a",
+		"mid:0 msid:stream1 video1 ssrc:4 cname:b ssrc:4 mslabel:stream1 ssrc:4 label:video1",
+	}
+	for i, it := range s.Media[1:] {
+		var p []string
+		for _, a := range it.Attributes {
+			p = append(p, a.String())
+		}
+		if v := strings.Join(p, " "); v != exp[i] {
+			t.Errorf("bad attributes of media %d: %s", i+1, v)
+		}
+	}
+	s.MergePlanB()
+	if len(s.Media) != 2 || s.Attributes.Get("group") != "BUNDLE audio video" {
+		t.Fatalf("bad merged session: %s", s)
+	}
+	tracks := planBTracks(s.Media[1])
+	if len(tracks) != 2 || tracks[0].msid != "stream0 video0" || tracks[1].msid != "stream1 video1" {
+		t.Fatalf("bad merged tracks: %s", s.Media[1].Attributes)
+	}
+}
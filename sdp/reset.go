@@ -0,0 +1,63 @@
+package sdp
+
+// Reset resets the session to be empty keeping allocated media descriptions, formats, attributes
+// and other values for reuse by Decoder.DecodeInto, for example when sessions are pooled.
+func (s *Session) Reset() {
+	*s = Session{
+		Email:      s.Email[:0],
+		Phone:      s.Phone[:0],
+		Bandwidth:  truncate(s.Bandwidth),
+		TimeZone:   s.TimeZone[:0],
+		Key:        truncate(s.Key),
+		Repeat:     s.Repeat[:0],
+		Attributes: truncate(s.Attributes),
+		Media:      truncate(s.Media),
+	}
+}
+
+func (m *Media) reset() {
+	*m = Media{
+		Connection: truncate(m.Connection),
+		Bandwidth:  truncate(m.Bandwidth),
+		Key:        truncate(m.Key),
+		Attributes: truncate(m.Attributes),
+		Format:     truncate(m.Format),
+	}
+}
+
+func (f *Format) reset(payload int) {
+	*f = Format{
+		Payload:   uint8(payload),
+		Name:      wellKnownName(payload),
+		ClockRate: wellKnownClockRate(payload),
+		Channels:  1,
+		Feedback:  f.Feedback[:0],
+		Params:    f.Params[:0],
+	}
+}
+
+// truncate returns the empty slice keeping its values for reuse by next.
+// Values beyond the length are cleared, since slices filtered in place may keep there
+// duplicates of retained values, which must not be reused twice.
+func truncate[T any](p []*T) []*T {
+	tail := p[len(p):cap(p)]
+	for i := range tail {
+		tail[i] = nil
+	}
+	return p[:0]
+}
+
+// next extends the slice by one element reusing the value left in its capacity by reset, if any.
+// The returned value is not cleared.
+func next[T any](p []*T) ([]*T, *T) {
+	n := len(p)
+	if n < cap(p) {
+		if p = p[:n+1]; p[n] != nil {
+			return p, p[n]
+		}
+	} else {
+		p = append(p, nil)
+	}
+	p[n] = new(T)
+	return p, p[n]
+}
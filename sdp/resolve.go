@@ -0,0 +1,148 @@
+package sdp
+
+import "strings"
+
+// EffectiveConnection returns the first connection data of the media,
+// or the session-level connection data if the media has none.
+func (s *Session) EffectiveConnection(m *Media) *Connection {
+	if len(m.Connection) > 0 {
+		return m.Connection[0]
+	}
+	return s.Connection
+}
+
+// EffectiveMode returns the streaming mode of the media, or the session-level mode
+// if the media has none. It returns SendRecv if neither is specified.
+func (s *Session) EffectiveMode(m *Media) string {
+	if m.Mode != "" {
+		return m.Mode
+	}
+	return strdef(s.Mode, SendRecv)
+}
+
+// EffectiveAttr returns the media attribute value by name, or the session-level attribute value
+// if the media has none.
+func (s *Session) EffectiveAttr(m *Media, name string) string {
+	if v := m.Attributes.Get(name); v != "" {
+		return v
+	}
+	return s.Attributes.Get(name)
+}
+
+// inheritedAttr lists attributes that apply to all media descriptions when specified at session level.
+var inheritedAttr = []string{"ice-ufrag", "ice-pwd", "ice-options", "fingerprint", "setup"}
+
+// Expand returns a copy of the session where each media description has resolved connection data,
+// bandwidth, streaming mode, ICE credentials and options, and DTLS fingerprint and setup role.
+// Session-level connection data, mode and inherited attributes are removed,
+// session-level bandwidth is kept and copied to media descriptions without bandwidth except CT.
+func (s *Session) Expand() *Session {
+	c := s.Clone()
+	for _, m := range c.Media {
+		if len(m.Connection) == 0 && c.Connection != nil {
+			m.Connection = []*Connection{c.Connection.Clone()}
+		}
+		if len(m.Bandwidth) == 0 {
+			for _, b := range c.Bandwidth {
+				if !strings.EqualFold(b.Type, BandwidthCT) {
+					m.Bandwidth = append(m.Bandwidth, &Bandwidth{Type: b.Type, Value: b.Value})
+				}
+			}
+		}
+		m.Mode = c.EffectiveMode(m)
+		for _, name := range inheritedAttr {
+			if m.Attributes.Has(name) {
+				continue
+			}
+			for _, a := range c.Attributes {
+				if a.Name == name {
+					m.Attributes = append(m.Attributes, NewAttr(a.Name, a.Value))
+				}
+			}
+		}
+	}
+	if len(c.Media) > 0 {
+		c.Connection = nil
+		c.Mode = ""
+		c.Attributes = DeleteAttr(c.Attributes, inheritedAttr...)
+	}
+	return c
+}
+
+// Compact returns a copy of the session where connection data, bandwidth, streaming mode and
+// inherited attributes shared by all enabled media descriptions are moved to session level.
+// Media bandwidth is removed if it is equal to the session-level one.
+func (s *Session) Compact() *Session {
+	c := s.Clone()
+	var media []*Media
+	for _, m := range c.Media {
+		if !m.Disabled() {
+			media = append(media, m)
+		}
+	}
+	if len(media) == 0 {
+		return c
+	}
+	first := media[0]
+	if same(media, func(m *Media) bool { return len(m.Connection) == 1 && m.Connection[0].equal(first.Connection[0]) }) &&
+		(c.Connection == nil || c.Connection.equal(first.Connection[0])) {
+		c.Connection = first.Connection[0]
+		for _, m := range c.Media {
+			if len(m.Connection) == 1 && m.Connection[0].equal(c.Connection) {
+				m.Connection = nil
+			}
+		}
+	}
+	var bw []*Bandwidth
+	for _, b := range c.Bandwidth {
+		if !strings.EqualFold(b.Type, BandwidthCT) {
+			bw = append(bw, b)
+		}
+	}
+	if same(media, func(m *Media) bool { return equalBandwidth(m.Bandwidth, first.Bandwidth) }) &&
+		(len(bw) == 0 || equalBandwidth(bw, first.Bandwidth)) {
+		if len(bw) == 0 {
+			c.Bandwidth = append(c.Bandwidth, cloneBandwidth(first.Bandwidth)...)
+			bw = first.Bandwidth
+		}
+		for _, m := range c.Media {
+			if equalBandwidth(m.Bandwidth, bw) {
+				m.Bandwidth = nil
+			}
+		}
+	}
+	mode := c.EffectiveMode(first)
+	if same(media, func(m *Media) bool { return c.EffectiveMode(m) == mode }) {
+		if c.Mode != "" || mode != SendRecv {
+			c.Mode = mode
+		}
+		for _, m := range media {
+			m.Mode = ""
+		}
+	}
+	for _, name := range inheritedAttr {
+		attrs := filterAttr(nil, first.Attributes, name)
+		if len(attrs) == 0 || c.Attributes.Has(name) {
+			continue
+		}
+		if !same(media, func(m *Media) bool { return equalAttr(filterAttr(nil, m.Attributes, name), attrs) }) {
+			continue
+		}
+		c.Attributes = append(c.Attributes, attrs...)
+		for _, m := range c.Media {
+			if equalAttr(filterAttr(nil, m.Attributes, name), attrs) {
+				m.Attributes = DeleteAttr(m.Attributes, name)
+			}
+		}
+	}
+	return c
+}
+
+func same(media []*Media, fn func(m *Media) bool) bool {
+	for _, m := range media {
+		if !fn(m) {
+			return false
+		}
+	}
+	return true
+}
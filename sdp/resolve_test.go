@@ -0,0 +1,75 @@
+package sdp
+
+import "testing"
+
+func TestExpandCompact(t *testing.T) {
+	s := &Session{
+		Connection: &Connection{Network: "IN", Type: "IP4", Address: "192.0.2.1"},
+		Bandwidth:  []*Bandwidth{{BandwidthCT, 1000}, {BandwidthAS, 500}},
+		Mode:       SendOnly,
+		Attributes: Attributes{NewAttr("ice-ufrag", "F7gI"), NewAttr("fingerprint", "sha-256 AB:CD"), NewAttr("group", "BUNDLE 0 1")},
+		Media: []*Media{
+			{Type: "audio", Port: 9, Attributes: Attributes{NewAttr("mid", "0")}},
+			{Type: "video", Port: 9, Mode: Inactive, Connection: []*Connection{{Network: "IN", Type: "IP4", Address: "192.0.2.2"}}, Attributes: Attributes{NewAttr("mid", "1"), NewAttr("ice-ufrag", "x9Pk")}},
+		},
+	}
+	if c := s.EffectiveConnection(s.Media[0]); c != s.Connection {
+		t.Errorf("bad effective connection: %v", c)
+	}
+	if v := s.EffectiveMode(s.Media[1]); v != Inactive {
+		t.Errorf("bad effective mode: %s", v)
+	}
+	e := s.Expand()
+	if e.Connection != nil || e.Mode != "" || e.Attributes.Has("ice-ufrag") || !e.Attributes.Has("group") || len(e.Bandwidth) != 2 {
+		t.Fatalf("bad expanded session: %v", e)
+	}
+	a, v := e.Media[0], e.Media[1]
+	if a.Connection[0].Address != "192.0.2.1" || a.Mode != SendOnly || a.Attributes.Get("ice-ufrag") != "F7gI" ||
+		a.Attributes.Get("fingerprint") != "sha-256 AB:CD" || len(a.Bandwidth) != 1 || a.Bandwidth[0].Type != BandwidthAS {
+		t.Errorf("bad expanded audio: %v", a)
+	}
+	if v.Connection[0].Address != "192.0.2.2" || v.Mode != Inactive || v.Attributes.Get("ice-ufrag") != "x9Pk" {
+		t.Errorf("bad expanded video: %v", v)
+	}
+	if s.Media[0].Connection != nil || s.Connection == nil {
+		t.Errorf("expand modified the original session")
+	}
+	v.Connection[0].Address = "192.0.2.1"
+	v.Mode = SendOnly
+	v.Attributes = DeleteAttr(v.Attributes, "ice-ufrag")
+	v.Attributes = append(v.Attributes, NewAttr("ice-ufrag", "F7gI"))
+	c := e.Compact()
+	if x := c.Expand(); !x.Equal(e) {
+		t.Errorf("compact changed the session: %v", x)
+	}
+	if c.Connection == nil || c.Connection.Address != "192.0.2.1" || c.Mode != SendOnly || c.Attributes.Get("ice-ufrag") != "F7gI" {
+		t.Fatalf("bad compact session: %v", c)
+	}
+	for _, m := range c.Media {
+		if m.Connection != nil || m.Mode != "" || m.Bandwidth != nil || m.Attributes.Has("ice-ufrag") || m.Attributes.Has("fingerprint") {
+			t.Errorf("bad compact media: %v", m)
+		}
+	}
+}
+
+func TestExpandRepeated(t *testing.T) {
+	s := &Session{
+		Attributes: Attributes{NewAttr("fingerprint", "sha-256 AB:CD"), NewAttr("fingerprint", "sha-1 EF:01")},
+		Media: []*Media{
+			{Type: "audio", Port: 9},
+			{Type: "video", Port: 9, Attributes: Attributes{NewAttr("fingerprint", "sha-256 23:45")}},
+		},
+	}
+	e := s.Expand()
+	a, v := e.Media[0], e.Media[1]
+	if len(e.Attributes) != 0 || !equalAttr(filterAttr(nil, a.Attributes, "fingerprint"), s.Attributes) {
+		t.Fatalf("bad expanded fingerprints: %v", a.Attributes)
+	}
+	if len(v.Attributes) != 1 || v.Attributes.Get("fingerprint") != "sha-256 23:45" {
+		t.Fatalf("bad media fingerprints: %v", v.Attributes)
+	}
+	v.Attributes = a.Attributes.Clone()
+	if c := e.Compact(); !equalAttr(c.Attributes, s.Attributes) || len(c.Media[0].Attributes) != 0 || len(c.Media[1].Attributes) != 0 {
+		t.Fatalf("bad compact fingerprints: %v", c.Attributes)
+	}
+}
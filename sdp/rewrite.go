@@ -0,0 +1,181 @@
+package sdp
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Change kinds reported only by Rewrite.
+const (
+	ChangeOrigin    = "origin-changed"
+	ChangeRTCP      = "rtcp-changed"      // RTCP port or address ("a=rtcp") changed
+	ChangeCandidate = "candidate-changed" // ICE candidate address or port changed
+)
+
+// Rewrite maps transport addresses of the session, for example private addresses to public
+// addresses of a media relay. The mapping function is called for the origin address with zero port,
+// connection addresses with media ports, "a=rtcp" addresses and ports, and "a=candidate" connection
+// and related ("raddr" and "rport") addresses and ports.
+// Connection data inherited from the session is rewritten for the first media using it, media with
+// a different mapped address get their own connection data. Disabled media and addresses which are
+// not IP literals are left intact. It returns the list of changed fields.
+func (s *Session) Rewrite(fn func(netip.AddrPort) netip.AddrPort) Changes {
+	var r Changes
+	add := func(kind string, media int, from, to string) {
+		r = append(r, &Change{Kind: kind, Media: media, Old: from, New: to})
+	}
+	if o := s.Origin; o != nil {
+		if v, ok := rewriteAddr(fn, o.Address, 0); ok && v.Addr().String() != o.Address {
+			old := o.Address
+			o.Type, o.Address = addrType(v.Addr()), v.Addr().String()
+			add(ChangeOrigin, -1, old, o.Address)
+		}
+	}
+	var conn netip.Addr
+	var mapped bool
+	if s.Connection != nil {
+		conn = s.Connection.Addr()
+	}
+	for i, m := range s.Media {
+		if m.Disabled() {
+			continue
+		}
+		port := m.Port
+		var from, to netip.Addr
+		if len(m.Connection) > 0 {
+			for j, c := range m.Connection {
+				v, ok := rewriteAddr(fn, c.Address, port)
+				if !ok {
+					continue
+				}
+				if j == 0 {
+					from, to, m.Port = c.Addr(), v.Addr(), int(v.Port())
+				}
+				if v.Addr() != c.Addr() {
+					old := connString(c)
+					c.Type, c.Address = addrType(v.Addr()), v.Addr().String()
+					add(ChangeConnection, i, old, connString(c))
+				}
+			}
+		} else if v, ok := mapAddr(fn, conn, port); ok {
+			from, to, m.Port = conn, v.Addr(), int(v.Port())
+			switch {
+			case !mapped:
+				mapped = true
+				if v.Addr() != conn {
+					old := connString(s.Connection)
+					s.Connection.Type, s.Connection.Address = addrType(v.Addr()), v.Addr().String()
+					add(ChangeConnection, -1, old, connString(s.Connection))
+				}
+			case s.Connection.Addr() != v.Addr():
+				c := s.Connection.Clone()
+				c.Type, c.Address = addrType(v.Addr()), v.Addr().String()
+				m.Connection = []*Connection{c}
+				add(ChangeConnection, i, connString(s.Connection), connString(c))
+			}
+		}
+		if m.Port != port {
+			add(ChangePort, i, strconv.Itoa(port), strconv.Itoa(m.Port))
+		}
+		for _, a := range m.Attributes {
+			switch a.Name {
+			case "rtcp":
+				if v := rewriteRTCP(fn, a.Value, from, to); v != a.Value {
+					add(ChangeRTCP, i, a.Value, v)
+					a.Value = v
+				}
+			case "candidate":
+				if v := rewriteCandidate(fn, a.Value); v != a.Value {
+					add(ChangeCandidate, i, a.Value, v)
+					a.Value = v
+				}
+			}
+		}
+	}
+	if !mapped && conn.IsValid() {
+		if v, ok := mapAddr(fn, conn, 0); ok && v.Addr() != conn {
+			old := connString(s.Connection)
+			s.Connection.Type, s.Connection.Address = addrType(v.Addr()), v.Addr().String()
+			add(ChangeConnection, -1, old, connString(s.Connection))
+		}
+	}
+	return r
+}
+
+func rewriteAddr(fn func(netip.AddrPort) netip.AddrPort, address string, port int) (netip.AddrPort, bool) {
+	return mapAddr(fn, parseAddr(address), port)
+}
+
+func mapAddr(fn func(netip.AddrPort) netip.AddrPort, addr netip.Addr, port int) (netip.AddrPort, bool) {
+	if !addr.IsValid() || port < 0 || port > 0xffff {
+		return netip.AddrPort{}, false
+	}
+	v := fn(netip.AddrPortFrom(addr, uint16(port)))
+	return v, v.IsValid()
+}
+
+// rewriteRTCP rewrites the "a=rtcp" attribute value. The RTCP address defaults to the RTP address from,
+// it is added to the value if the mapped address differs from the mapped RTP address to.
+func rewriteRTCP(fn func(netip.AddrPort) netip.AddrPort, v string, from, to netip.Addr) string {
+	p := strings.Fields(v)
+	if len(p) != 1 && len(p) != 4 {
+		return v
+	}
+	port, err := strconv.Atoi(p[0])
+	if err != nil {
+		return v
+	}
+	address := from.String()
+	if len(p) == 4 {
+		address = p[3]
+	} else if !from.IsValid() {
+		return v
+	}
+	a, ok := rewriteAddr(fn, address, port)
+	if !ok || int(a.Port()) == port && (len(p) == 4 && a.Addr().String() == address || len(p) == 1 && a.Addr() == to) {
+		return v
+	}
+	p[0] = strconv.Itoa(int(a.Port()))
+	switch {
+	case len(p) == 4:
+		p[2], p[3] = addrType(a.Addr()), a.Addr().String()
+	case a.Addr() != to:
+		p = append(p, NetworkInternet, addrType(a.Addr()), a.Addr().String())
+	}
+	return strings.Join(p, " ")
+}
+
+// rewriteCandidate rewrites the connection address and port, and the related address and port
+// ("raddr" and "rport") of the "a=candidate" attribute value.
+func rewriteCandidate(fn func(netip.AddrPort) netip.AddrPort, v string) string {
+	p := strings.Fields(v)
+	if len(p) < 6 {
+		return v
+	}
+	changed := rewriteAddrPort(fn, p, 4, 5)
+	for i := 6; i+3 < len(p); i += 2 {
+		if p[i] == "raddr" && p[i+2] == "rport" {
+			changed = rewriteAddrPort(fn, p, i+1, i+3) || changed
+			break
+		}
+	}
+	if !changed {
+		return v
+	}
+	return strings.Join(p, " ")
+}
+
+// rewriteAddrPort rewrites the address and port fields of p by indexes and reports whether they changed.
+func rewriteAddrPort(fn func(netip.AddrPort) netip.AddrPort, p []string, addr, port int) bool {
+	n, err := strconv.Atoi(p[port])
+	if err != nil {
+		return false
+	}
+	a, ok := rewriteAddr(fn, p[addr], n)
+	if !ok || int(a.Port()) == n && a.Addr().String() == p[addr] {
+		return false
+	}
+	p[addr], p[port] = a.Addr().String(), strconv.Itoa(int(a.Port()))
+	return true
+}
@@ -0,0 +1,42 @@
+package sdp
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	s, err := ParseString("v=0\r\no=- 1 1 IN IP4 10.0.0.1\r\ns=-\r\nc=IN IP4 10.0.0.1\r\nt=0 0\r\n" +
+		"m=audio 5004 RTP/AVP 0\r\na=rtcp:5005\r\na=candidate:1 1 UDP 2130706431 10.0.0.1 5004 typ host\r\n" +
+		"m=video 5006 RTP/AVP 31\r\na=rtcp:5007 IN IP4 10.0.0.1\r\n" +
+		"a=candidate:2 1 UDP 1694498815 192.0.2.3 6006 typ srflx raddr 10.0.0.1 rport 5006 generation 0\r\n" +
+		"m=text 5008 RTP/AVP 98\r\nc=IN IP4 192.0.2.5\r\n" +
+		"m=video 0 RTP/AVP 31\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	relay := netip.MustParseAddr("203.0.113.1")
+	changes := s.Rewrite(func(v netip.AddrPort) netip.AddrPort {
+		if !v.Addr().IsPrivate() {
+			return v
+		}
+		if v.Port() == 0 {
+			return netip.AddrPortFrom(relay, 0)
+		}
+		return netip.AddrPortFrom(relay, v.Port()+10000)
+	})
+	exp := `session: origin changed: 10.0.0.1 -> 203.0.113.1
+session: connection changed: IN IP4 10.0.0.1 -> IN IP4 203.0.113.1
+media 0: port changed: 5004 -> 15004
+media 0: rtcp changed: 5005 -> 15005
+media 0: candidate changed: 1 1 UDP 2130706431 10.0.0.1 5004 typ host -> 1 1 UDP 2130706431 203.0.113.1 15004 typ host
+media 1: port changed: 5006 -> 15006
+media 1: rtcp changed: 5007 IN IP4 10.0.0.1 -> 15007 IN IP4 203.0.113.1
+media 1: candidate changed: 2 1 UDP 1694498815 192.0.2.3 6006 typ srflx raddr 10.0.0.1 rport 5006 generation 0 -> 2 1 UDP 1694498815 192.0.2.3 6006 typ srflx raddr 203.0.113.1 rport 15006 generation 0`
+	if v := changes.String(); v != exp {
+		t.Errorf("bad changes:\n%s\nexpected:\n%s", v, exp)
+	}
+	if s.Media[2].Port != 5008 || s.Media[2].Connection[0].Address != "192.0.2.5" || s.Media[3].Port != 0 {
+		t.Errorf("unexpected rewrite: %v", s)
+	}
+}
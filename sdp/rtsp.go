@@ -0,0 +1,311 @@
+package sdp
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ControlURL returns the aggregate control URL ("a=control") of the session resolved against base.
+// The base is the Content-Base, Content-Location or request URL of the RTSP DESCRIBE response.
+// If the session has no control attribute or it is "*", base is returned.
+func (s *Session) ControlURL(base string) (string, error) {
+	return resolveControl(base, s.Attributes.Get("control"))
+}
+
+// MediaControlURL returns the control URL ("a=control") of the media resolved against
+// the session control URL, or against base if the session has no control attribute.
+func (s *Session) MediaControlURL(m *Media, base string) (string, error) {
+	base, err := s.ControlURL(base)
+	if err != nil {
+		return "", err
+	}
+	return resolveControl(base, m.Attributes.Get("control"))
+}
+
+// Range returns the session presentation time range ("a=range").
+// It returns nil if the session has no range attribute.
+func (s *Session) Range() (*Range, error) {
+	if v := s.Attributes.Get("range"); v != "" {
+		return ParseRange(v)
+	}
+	return nil, nil
+}
+
+// Range returns the media presentation time range ("a=range").
+// It returns nil if the media has no range attribute.
+func (m *Media) Range() (*Range, error) {
+	if v := m.Attributes.Get("range"); v != "" {
+		return ParseRange(v)
+	}
+	return nil, nil
+}
+
+func resolveControl(base, control string) (string, error) {
+	if control == "" || control == "*" {
+		return base, nil
+	}
+	ref, err := url.Parse(control)
+	if err != nil {
+		return "", err
+	}
+	if ref.IsAbs() {
+		return control, nil
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	if !u.IsAbs() {
+		return "", errRelativeControl
+	}
+	// Most servers send the Content-Base without a trailing slash and
+	// expect the control path to be appended rather than replace the last segment.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		if u.RawPath != "" {
+			u.RawPath += "/"
+		}
+	}
+	return u.ResolveReference(ref).String(), nil
+}
+
+// Range units.
+const (
+	RangeNPT         = "npt"
+	RangeSMPTE       = "smpte"
+	RangeSMPTE30Drop = "smpte-30-drop"
+	RangeSMPTE25     = "smpte-25"
+	RangeClock       = "clock"
+)
+
+// Range represents a presentation time range as defined in RFC 2326 section 3.5-3.7.
+type Range struct {
+	Unit  string     // RangeNPT, RangeSMPTE, RangeSMPTE30Drop, RangeSMPTE25 or RangeClock
+	Start *RangeTime // Start of the range or nil if omitted
+	End   *RangeTime // End of the range or nil if open
+}
+
+// RangeTime represents a start or an end of the presentation time range.
+type RangeTime struct {
+	Now       bool          // NPT "now" for live presentations
+	Offset    time.Duration // NPT time or SMPTE time without frames
+	Frames    int           // SMPTE frames
+	Subframes int           // SMPTE subframes in hundredths of a frame
+	Time      time.Time     // Absolute UTC time for clock range
+}
+
+// ParseRange parses the range attribute value, e.g. "npt=0-34.5".
+func ParseRange(v string) (*Range, error) {
+	i := strings.IndexByte(v, '=')
+	if i < 0 {
+		return nil, errRange
+	}
+	r := &Range{Unit: v[:i]}
+	v = v[i+1:]
+	var parse func(string) (*RangeTime, error)
+	switch r.Unit {
+	case RangeNPT:
+		parse = parseNPT
+	case RangeSMPTE, RangeSMPTE30Drop, RangeSMPTE25:
+		parse = parseSMPTE
+	case RangeClock:
+		parse = parseClock
+	default:
+		return nil, errRange
+	}
+	i = strings.IndexByte(v, '-')
+	if i < 0 {
+		return nil, errRange
+	}
+	start, end := v[:i], v[i+1:]
+	if start == "" && end == "" {
+		return nil, errRange
+	}
+	var err error
+	if start != "" {
+		if r.Start, err = parse(start); err != nil {
+			return nil, err
+		}
+	}
+	if end != "" {
+		if r.End, err = parse(end); err != nil {
+			return nil, err
+		}
+		if r.End.Now {
+			return nil, errRange
+		}
+	}
+	return r, nil
+}
+
+// String returns the encoded range attribute value.
+func (r *Range) String() string {
+	b := append([]byte(r.Unit), '=')
+	if r.Start != nil {
+		b = r.Start.append(b, r.Unit)
+	}
+	b = append(b, '-')
+	if r.End != nil {
+		b = r.End.append(b, r.Unit)
+	}
+	return string(b)
+}
+
+// Duration returns the length of the NPT or clock range.
+// It returns zero if the range is open or starts at "now".
+func (r *Range) Duration() time.Duration {
+	if r.End == nil || r.Start != nil && r.Start.Now {
+		return 0
+	}
+	var start RangeTime
+	if r.Start != nil {
+		start = *r.Start
+	}
+	switch r.Unit {
+	case RangeNPT:
+		return r.End.Offset - start.Offset
+	case RangeClock:
+		if start.Time.IsZero() {
+			return 0
+		}
+		return r.End.Time.Sub(start.Time)
+	}
+	return 0
+}
+
+func (t *RangeTime) append(b []byte, unit string) []byte {
+	switch unit {
+	case RangeNPT:
+		if t.Now {
+			return append(b, "now"...)
+		}
+		sec := int64(t.Offset / time.Second)
+		b = strconv.AppendInt(b, sec, 10)
+		if ns := int64(t.Offset % time.Second); ns > 0 {
+			f := strconv.AppendInt(nil, ns+1e9, 10)
+			b = append(b, '.')
+			b = append(b, strings.TrimRight(string(f[1:]), "0")...)
+		}
+		return b
+	case RangeClock:
+		return append(b, t.Time.UTC().Format(clockLayout)...)
+	default:
+		sec := int(t.Offset / time.Second)
+		b = appendInt2(b, sec/3600)
+		b = appendInt2(append(b, ':'), sec/60%60)
+		b = appendInt2(append(b, ':'), sec%60)
+		if t.Frames > 0 || t.Subframes > 0 {
+			b = appendInt2(append(b, ':'), t.Frames)
+			if t.Subframes > 0 {
+				b = appendInt2(append(b, '.'), t.Subframes)
+			}
+		}
+		return b
+	}
+}
+
+func appendInt2(b []byte, v int) []byte {
+	if v < 10 {
+		b = append(b, '0')
+	}
+	return strconv.AppendInt(b, int64(v), 10)
+}
+
+// parseNPT parses npt-time: "now", npt-sec or npt-hhmmss.
+func parseNPT(v string) (*RangeTime, error) {
+	if v == "now" {
+		return &RangeTime{Now: true}, nil
+	}
+	var frac string
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		v, frac = v[:i], v[i+1:]
+	}
+	p := strings.Split(v, ":")
+	if len(p) != 1 && len(p) != 3 {
+		return nil, errRange
+	}
+	var sec int64
+	for i, it := range p {
+		n, err := strconv.ParseUint(it, 10, 32)
+		if err != nil {
+			return nil, errRange
+		}
+		if i > 0 && n > 59 {
+			return nil, errRange
+		}
+		sec = sec*60 + int64(n)
+	}
+	ns, err := parseFraction(frac)
+	if err != nil {
+		return nil, err
+	}
+	return &RangeTime{Offset: time.Duration(sec)*time.Second + ns}, nil
+}
+
+func parseFraction(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, nil
+	}
+	if len(v) > 9 {
+		v = v[:9]
+	}
+	n, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, errRange
+	}
+	for i := len(v); i < 9; i++ {
+		n *= 10
+	}
+	return time.Duration(n), nil
+}
+
+// parseSMPTE parses smpte-time: hh:mm:ss[:frames[.subframes]].
+func parseSMPTE(v string) (*RangeTime, error) {
+	var sub string
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		v, sub = v[:i], v[i+1:]
+	}
+	p := strings.Split(v, ":")
+	if len(p) != 3 && len(p) != 4 || sub != "" && len(p) != 4 {
+		return nil, errRange
+	}
+	if sub != "" {
+		p = append(p, sub)
+	}
+	n := make([]int, 5)
+	for i, it := range p {
+		if len(it) > 2 {
+			return nil, errRange
+		}
+		x, err := strconv.Atoi(it)
+		if err != nil || x < 0 {
+			return nil, errRange
+		}
+		n[i] = x
+	}
+	if n[1] > 59 || n[2] > 59 {
+		return nil, errRange
+	}
+	return &RangeTime{
+		Offset:    time.Duration(n[0]*3600+n[1]*60+n[2]) * time.Second,
+		Frames:    n[3],
+		Subframes: n[4],
+	}, nil
+}
+
+const clockLayout = "20060102T150405.999999999Z"
+
+// parseClock parses utc-time: yyyymmddThhmmss[.fraction]Z.
+func parseClock(v string) (*RangeTime, error) {
+	t, err := time.Parse(clockLayout, v)
+	if err != nil {
+		return nil, errRange
+	}
+	return &RangeTime{Time: t}, nil
+}
+
+var errRange = errors.New("sdp: invalid range format")
+var errRelativeControl = errors.New("sdp: control url is relative to unknown base")
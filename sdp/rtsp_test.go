@@ -0,0 +1,72 @@
+package sdp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestControlURL(t *testing.T) {
+	sess, err := ParseString(`v=0
+o=- 0 0 IN IP4 127.0.0.1
+s=-
+t=0 0
+a=control:*
+a=range:npt=0-34.5
+m=video 0 RTP/AVP 96
+a=rtpmap:96 H264/90000
+a=control:trackID=1
+m=audio 0 RTP/AVP 0
+a=control:rtsp://example.com/media/audio
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := "rtsp://example.com/media.mp4"
+	for i, exp := range []string{"rtsp://example.com/media.mp4/trackID=1", "rtsp://example.com/media/audio"} {
+		u, err := sess.MediaControlURL(sess.Media[i], base)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u != exp {
+			t.Errorf("bad control url for media %d: %s, expected: %s", i, u, exp)
+		}
+	}
+	if u, _ := sess.ControlURL(base); u != base {
+		t.Errorf("bad session control url: %s", u)
+	}
+	r, err := sess.Range()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Duration() != 34500*time.Millisecond {
+		t.Errorf("bad range duration: %v", r.Duration())
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	for _, v := range []string{
+		"npt=0-34.5",
+		"npt=now-",
+		"npt=-20.125",
+		"smpte=10:07:00-10:07:33:05.01",
+		"smpte-25=00:00:10:12-",
+		"clock=19961108T142300Z-19961108T143520.25Z",
+	} {
+		r, err := ParseRange(v)
+		if err != nil {
+			t.Fatalf("parse %s: %v", v, err)
+		}
+		if r.String() != v {
+			t.Errorf("bad range: %s, expected: %s", r, v)
+		}
+	}
+	r, _ := ParseRange("npt=00:01:02.5-")
+	if r.Start.Offset != 62500*time.Millisecond || r.End != nil {
+		t.Errorf("bad npt range: %+v", r)
+	}
+	for _, v := range []string{"npt=-", "npt=1-now", "smpte=1:2-", "foo=1-2", "clock=1996-"} {
+		if _, err := ParseRange(v); err == nil {
+			t.Errorf("expected error for %s", v)
+		}
+	}
+}
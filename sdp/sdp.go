@@ -10,22 +10,22 @@ const ContentType = "application/sdp"
 
 // Session represents an SDP session description.
 type Session struct {
-	Version     int          // Protocol Version ("v=")
-	Origin      *Origin      // Origin ("o=")
-	Name        string       // Session Name ("s=")
-	Information string       // Session Information ("i=")
-	URI         string       // URI ("u=")
-	Email       []string     // Email Address ("e=")
-	Phone       []string     // Phone Number ("p=")
-	Connection  *Connection  // Connection Data ("c=")
-	Bandwidth   []*Bandwidth // Bandwidth ("b=")
-	TimeZone    []*TimeZone  // TimeZone ("z=")
-	Key         []*Key       // Encryption Keys ("k=")
-	Timing      *Timing      // Timing ("t=")
-	Repeat      []*Repeat    // Repeat Times ("r=")
-	Attributes  Attributes   // Session Attributes ("a=")
-	Mode        string       // Streaming mode ("sendrecv", "recvonly", "sendonly", or "inactive")
-	Media       []*Media     // Media Descriptions ("m=")
+	Version     int          `json:"version"`               // Protocol Version ("v=")
+	Origin      *Origin      `json:"origin,omitempty"`      // Origin ("o=")
+	Name        string       `json:"name"`                  // Session Name ("s=")
+	Information string       `json:"information,omitempty"` // Session Information ("i=")
+	URI         string       `json:"uri,omitempty"`         // URI ("u=")
+	Email       []string     `json:"email,omitempty"`       // Email Address ("e=")
+	Phone       []string     `json:"phone,omitempty"`       // Phone Number ("p=")
+	Connection  *Connection  `json:"connection,omitempty"`  // Connection Data ("c=")
+	Bandwidth   []*Bandwidth `json:"bandwidth,omitempty"`   // Bandwidth ("b=")
+	TimeZone    []*TimeZone  `json:"timeZone,omitempty"`    // TimeZone ("z=")
+	Key         []*Key       `json:"key,omitempty"`         // Encryption Keys ("k=")
+	Timing      *Timing      `json:"timing,omitempty"`      // Timing ("t=")
+	Repeat      []*Repeat    `json:"repeat,omitempty"`      // Repeat Times ("r=")
+	Attributes  Attributes   `json:"attributes,omitempty"`  // Session Attributes ("a=")
+	Mode        string       `json:"mode,omitempty"`        // Streaming mode ("sendrecv", "recvonly", "sendonly", or "inactive")
+	Media       []*Media     `json:"media,omitempty"`       // Media Descriptions ("m=")
 }
 
 // String returns the encoded session description as string.
@@ -34,20 +34,19 @@ func (s *Session) String() string {
 }
 
 // Bytes returns the encoded session description as buffer.
+// Fields are not validated, use Encoder.Encode for session descriptions built from untrusted input.
 func (s *Session) Bytes() []byte {
-	e := NewEncoder(nil)
-	e.Encode(s)
-	return e.Bytes()
+	return AppendSession(make([]byte, 0, 1024), s)
 }
 
 // Origin represents an originator of the session.
 type Origin struct {
-	Username       string
-	SessionID      int64
-	SessionVersion int64
-	Network        string
-	Type           string
-	Address        string
+	Username       string `json:"username"`
+	SessionID      int64  `json:"sessionId"`
+	SessionVersion int64  `json:"sessionVersion"`
+	Network        string `json:"network"`
+	Type           string `json:"type"`
+	Address        string `json:"address"`
 }
 
 const (
@@ -61,17 +60,17 @@ const (
 
 // Connection contains connection data.
 type Connection struct {
-	Network    string
-	Type       string
-	Address    string
-	TTL        int
-	AddressNum int
+	Network    string `json:"network"`
+	Type       string `json:"type"`
+	Address    string `json:"address"`
+	TTL        int    `json:"ttl,omitempty"`
+	AddressNum int    `json:"addressNum,omitempty"`
 }
 
 // Bandwidth contains session or media bandwidth information.
 type Bandwidth struct {
-	Type  string
-	Value int
+	Type  string `json:"type"`
+	Value int    `json:"value"`
 }
 
 // TimeZone represents a time zones change information for a repeated session.
@@ -83,7 +82,8 @@ type TimeZone struct {
 // Key contains a key exchange information.
 // Deprecated. Use for backwards compatibility only.
 type Key struct {
-	Method, Value string
+	Method string `json:"method"`
+	Value  string `json:"value,omitempty"`
 }
 
 // Timing specifies start and stop times for a session.
@@ -149,6 +149,35 @@ func NegotiateMode(local, remote string) string {
 	return Inactive
 }
 
+// NegotiateFormat returns local formats supported by remote in order of local preference.
+// Formats are matched by encoding name, clock rate and number of channels.
+func NegotiateFormat(local, remote []*Format) []*Format {
+	var r []*Format
+	for _, l := range local {
+		for _, it := range remote {
+			if l.match(it) {
+				r = append(r, l)
+				break
+			}
+		}
+	}
+	return r
+}
+
+func (f *Format) match(o *Format) bool {
+	if f.Name == "" || o.Name == "" {
+		return f.Name == o.Name && f.Payload == o.Payload
+	}
+	return strings.EqualFold(f.Name, o.Name) && f.ClockRate == o.ClockRate && f.channels() == o.channels()
+}
+
+func (f *Format) channels() int {
+	if f.Channels == 0 {
+		return 1
+	}
+	return f.Channels
+}
+
 // DeleteAttr removes all elements with name from attrs.
 func DeleteAttr(attrs Attributes, name ...string) Attributes {
 	n := 0
@@ -162,6 +191,9 @@ loop:
 		attrs[n] = it
 		n++
 	}
+	for i := n; i < len(attrs); i++ {
+		attrs[i] = nil
+	}
 	return attrs[:n]
 }
 
@@ -177,12 +209,12 @@ func (m *Media) FormatByPayload(payload uint8) *Format {
 
 // Format is a media format description represented by "rtpmap" attributes.
 type Format struct {
-	Payload   uint8
-	Name      string
-	ClockRate int
-	Channels  int
-	Feedback  []string // "rtcp-fb" attributes
-	Params    []string // "fmtp" attributes
+	Payload   uint8    `json:"payload"`
+	Name      string   `json:"name"`
+	ClockRate int      `json:"clockRate"`
+	Channels  int      `json:"channels"`
+	Feedback  []string `json:"feedback,omitempty"` // "rtcp-fb" attributes
+	Params    []string `json:"params,omitempty"`   // "fmtp" attributes
 }
 
 func (f *Format) String() string {
@@ -336,6 +336,46 @@ func TestVectors(t *testing.T) {
 	}
 }
 
+func TestDecodeInto(t *testing.T) {
+	sess, d := new(Session), new(Decoder)
+	for i := 0; i < 2; i++ {
+		for _, v := range testVectors {
+			d.ResetBytes([]byte(v.Data))
+			if err := d.DecodeInto(sess); err != nil {
+				t.Fatal(err)
+			}
+			(&T{t}).AssertAny(v.Name, sess, v.Session)
+		}
+	}
+	if err := NewDecoderString("v=0\r\nx=1\r\n").DecodeInto(sess); err == nil {
+		t.Fatal("expected error")
+	}
+	sess.Reset()
+	if sess.Origin != nil || len(sess.Media) != 0 || len(sess.Attributes) != 0 {
+		t.Errorf("bad reset session: %v", sess)
+	}
+}
+
+func TestDecodeIntoFiltered(t *testing.T) {
+	const v = "v=0\r\no=- 1 1 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\na=a:1\r\na=b:2\r\na=c:3\r\n" +
+		"m=audio 5004 RTP/AVP 0\r\nb=AS:1\r\nb=AS:2\r\nb=TIAS:3\r\na=a:1\r\na=b:2\r\na=c:3\r\n"
+	sess, err := ParseString(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// In-place filtering leaves duplicate pointers beyond the length of slices.
+	sess.Attributes = DeleteAttr(sess.Attributes, "b")
+	sess.Media[0].Attributes = DeleteAttr(sess.Media[0].Attributes, "b")
+	sess.Media[0].SetBandwidth(BandwidthAS, 5)
+	sess.Reset()
+	if err = NewDecoderString(v).DecodeInto(sess); err != nil {
+		t.Fatal(err)
+	}
+	if got := sess.String(); got != v {
+		t.Errorf("bad session decoded into filtered one:\n%s\nexpected:\n%s", got, v)
+	}
+}
+
 type T struct {
 	*testing.T
 }
@@ -419,6 +459,42 @@ func BenchmarkDecodeReader(b *testing.B) {
 	}
 }
 
+func BenchmarkDecodeInto(b *testing.B) {
+	for _, v := range testVectors {
+		v := v
+		b.Run(v.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			data := []byte(v.Data)
+			d, sess := NewDecoderBytes(data), new(Session)
+			for i := 0; i < b.N; i++ {
+				d.ResetBytes(data)
+				if err := d.DecodeInto(sess); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkView(b *testing.B) {
+	for _, v := range testVectors {
+		v := v
+		b.Run(v.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			data := []byte(v.Data)
+			for i := 0; i < b.N; i++ {
+				view, err := NewView(data)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for j := 0; j < view.NumMedia(); j++ {
+					_ = view.Media(j).Port()
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkEncode(b *testing.B) {
 	for _, v := range testVectors {
 		v := v
@@ -0,0 +1,206 @@
+package sdp
+
+import "errors"
+
+// Signaling states as defined in JSEP (RFC 8829).
+const (
+	Stable             = "stable"
+	HaveLocalOffer     = "have-local-offer"
+	HaveRemoteOffer    = "have-remote-offer"
+	HaveLocalPrAnswer  = "have-local-pranswer"
+	HaveRemotePrAnswer = "have-remote-pranswer"
+)
+
+// Signaling implements the JSEP signaling state machine.
+// It stores current and pending local and remote session descriptions.
+// A Signaling is not safe for concurrent use.
+type Signaling struct {
+	state                        string
+	currentLocal, pendingLocal   *Session
+	currentRemote, pendingRemote *Session
+}
+
+// State returns the signaling state.
+func (s *Signaling) State() string {
+	if s.state == "" {
+		return Stable
+	}
+	return s.state
+}
+
+// CurrentLocal returns the local description negotiated in the last completed offer/answer exchange.
+func (s *Signaling) CurrentLocal() *Session {
+	return s.currentLocal
+}
+
+// PendingLocal returns the local offer or pranswer of the pending offer/answer exchange.
+func (s *Signaling) PendingLocal() *Session {
+	return s.pendingLocal
+}
+
+// CurrentRemote returns the remote description negotiated in the last completed offer/answer exchange.
+func (s *Signaling) CurrentRemote() *Session {
+	return s.currentRemote
+}
+
+// PendingRemote returns the remote offer or pranswer of the pending offer/answer exchange.
+func (s *Signaling) PendingRemote() *Session {
+	return s.pendingRemote
+}
+
+// Local returns the pending local description if any, or the current local description.
+func (s *Signaling) Local() *Session {
+	if s.pendingLocal != nil {
+		return s.pendingLocal
+	}
+	return s.currentLocal
+}
+
+// Remote returns the pending remote description if any, or the current remote description.
+func (s *Signaling) Remote() *Session {
+	if s.pendingRemote != nil {
+		return s.pendingRemote
+	}
+	return s.currentRemote
+}
+
+// SetLocal applies the local description of the type.
+// It returns an error if the transition is not allowed in the current state.
+func (s *Signaling) SetLocal(typ string, sess *Session) error {
+	return s.set(typ, sess, true)
+}
+
+// SetRemote applies the remote description of the type.
+// It returns an error if the transition is not allowed in the current state.
+func (s *Signaling) SetRemote(typ string, sess *Session) error {
+	return s.set(typ, sess, false)
+}
+
+// SetLocalDescription applies the local session description.
+func (s *Signaling) SetLocalDescription(d *SessionDescription) error {
+	sess, err := d.Session()
+	if err != nil {
+		return err
+	}
+	return s.SetLocal(d.Type, sess)
+}
+
+// SetRemoteDescription applies the remote session description.
+func (s *Signaling) SetRemoteDescription(d *SessionDescription) error {
+	sess, err := d.Session()
+	if err != nil {
+		return err
+	}
+	return s.SetRemote(d.Type, sess)
+}
+
+func (s *Signaling) set(typ string, sess *Session, local bool) error {
+	if err := checkDescriptionType(typ); err != nil {
+		return err
+	}
+	if typ != Rollback {
+		if sess == nil {
+			return errNoSession
+		}
+		if err := checkDescription(typ, sess); err != nil {
+			return err
+		}
+	}
+	state := s.State()
+	offerState := HaveLocalOffer
+	if !local {
+		offerState = HaveRemoteOffer
+	}
+	switch typ {
+	case Offer:
+		if state != Stable && state != offerState {
+			return errSignalingState
+		}
+		s.setPending(sess, local)
+		s.state = offerState
+	case PrAnswer, Answer:
+		// Answers are accepted in response to the opposite side offer only.
+		peerOffer, peerPrAnswer := HaveRemoteOffer, HaveLocalPrAnswer
+		if !local {
+			peerOffer, peerPrAnswer = HaveLocalOffer, HaveRemotePrAnswer
+		}
+		if state != peerOffer && state != peerPrAnswer {
+			return errSignalingState
+		}
+		if typ == PrAnswer {
+			s.setPending(sess, local)
+			s.state = peerPrAnswer
+			break
+		}
+		if local {
+			s.currentLocal, s.currentRemote = sess, s.pendingRemote
+		} else {
+			s.currentLocal, s.currentRemote = s.pendingLocal, sess
+		}
+		s.pendingLocal, s.pendingRemote = nil, nil
+		s.state = Stable
+	case Rollback:
+		if state != offerState {
+			return errSignalingState
+		}
+		s.pendingLocal, s.pendingRemote = nil, nil
+		s.state = Stable
+	}
+	return nil
+}
+
+func (s *Signaling) setPending(sess *Session, local bool) {
+	if local {
+		s.pendingLocal = sess
+	} else {
+		s.pendingRemote = sess
+	}
+}
+
+// MediaState represents the negotiated state of a media description.
+type MediaState struct {
+	Index    int       // Media description index
+	Mid      string    // Media identifier ("a=mid"), if any
+	Type     string    // Media type
+	Mode     string    // Local streaming mode negotiated by NegotiateMode
+	Format   []*Format // Local formats supported by remote in order of local preference
+	Rejected bool      // Media is rejected by either side with zero port
+}
+
+// Negotiated returns the negotiated state of media descriptions.
+// It uses the provisional answer and its offer while in a pranswer state,
+// and current descriptions otherwise. It returns nil if nothing is negotiated yet.
+func (s *Signaling) Negotiated() []*MediaState {
+	local, remote := s.currentLocal, s.currentRemote
+	switch s.State() {
+	case HaveLocalPrAnswer, HaveRemotePrAnswer:
+		local, remote = s.pendingLocal, s.pendingRemote
+	}
+	if local == nil || remote == nil {
+		return nil
+	}
+	var r []*MediaState
+	for i, l := range local.Media {
+		if i >= len(remote.Media) {
+			break
+		}
+		m := remote.Media[i]
+		it := &MediaState{
+			Index: i,
+			Mid:   l.Attributes.Get("mid"),
+			Type:  l.Type,
+			Mode:  Inactive,
+		}
+		if l.Port == 0 || m.Port == 0 {
+			it.Rejected = true
+		} else {
+			it.Mode = NegotiateMode(local.EffectiveMode(l), remote.EffectiveMode(m))
+			it.Format = NegotiateFormat(l.Format, m.Format)
+		}
+		r = append(r, it)
+	}
+	return r
+}
+
+var errSignalingState = errors.New("sdp: invalid signaling state transition")
+var errNoSession = errors.New("sdp: missing session description")
@@ -0,0 +1,267 @@
+package sdp
+
+import (
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// View is a read-only view of an encoded session description.
+// It indexes lines of the description and parses field values on demand
+// without building the Session, which is useful when only a few values are needed.
+type View struct {
+	s     string
+	lines []viewLine
+	media []int // Indexes of "m=" lines
+}
+
+type viewLine struct {
+	field      byte
+	start, end int // Value offsets
+}
+
+// NewView returns the view of the session description in b without copying.
+// Strings returned by the view refer to b, so b must not be modified while they are in use.
+func NewView(b []byte) (*View, error) {
+	return NewViewString(*(*string)(unsafe.Pointer(&b)))
+}
+
+// NewViewString returns the view of the session description in s.
+func NewViewString(s string) (*View, error) {
+	v := &View{s: s, lines: make([]viewLine, 0, strings.Count(s, "\n")+1)}
+	for pos, n := 0, 1; pos < len(s); n++ {
+		end := strings.IndexByte(s[pos:], '\n')
+		next := len(s)
+		if end < 0 {
+			end = len(s)
+		} else {
+			end += pos
+			next = end + 1
+		}
+		line := strings.TrimRight(s[pos:end], "\r")
+		if line == "" {
+			break
+		}
+		if len(line) < 2 || line[1] != '=' {
+			return nil, &errDecode{errFormat, n, line}
+		}
+		if line[0] == 'm' {
+			v.media = append(v.media, len(v.lines))
+		}
+		v.lines = append(v.lines, viewLine{field: line[0], start: pos + 2, end: pos + len(line)})
+		pos = next
+	}
+	if len(v.lines) == 0 {
+		return nil, &errDecode{errFormat, 1, ""}
+	}
+	return v, nil
+}
+
+// Session decodes the whole session description.
+func (v *View) Session() (*Session, error) {
+	return NewDecoderString(v.s).Decode()
+}
+
+// Field returns the value of the first session-level field, for example 's' for the session name.
+func (v *View) Field(f byte) string {
+	for i := 0; i < v.sessionEnd(); i++ {
+		if v.lines[i].field == f {
+			return v.value(i)
+		}
+	}
+	return ""
+}
+
+// Has checks presence of the session-level attribute by name.
+func (v *View) Has(name string) bool {
+	return v.has(0, v.sessionEnd(), name)
+}
+
+// Get returns the session-level attribute value by name.
+func (v *View) Get(name string) string {
+	return v.get(0, v.sessionEnd(), name)
+}
+
+// EachAttr calls fn for values of session-level attributes with the name until fn returns false.
+func (v *View) EachAttr(name string, fn func(value string) bool) {
+	v.each(0, v.sessionEnd(), name, fn)
+}
+
+// Mode returns the session-level streaming mode or an empty string if it is not specified.
+func (v *View) Mode() string {
+	return v.mode(0, v.sessionEnd())
+}
+
+// NumMedia returns the number of media descriptions.
+func (v *View) NumMedia() int {
+	return len(v.media)
+}
+
+// Media returns the view of the media description by index.
+func (v *View) Media(i int) MediaView {
+	end := len(v.lines)
+	if i+1 < len(v.media) {
+		end = v.media[i+1]
+	}
+	return MediaView{v: v, start: v.media[i], end: end}
+}
+
+func (v *View) sessionEnd() int {
+	if len(v.media) > 0 {
+		return v.media[0]
+	}
+	return len(v.lines)
+}
+
+func (v *View) value(i int) string {
+	l := v.lines[i]
+	return v.s[l.start:l.end]
+}
+
+func (v *View) has(start, end int, name string) bool {
+	found := false
+	v.each(start, end, name, func(string) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+func (v *View) get(start, end int, name string) (r string) {
+	v.each(start, end, name, func(value string) bool {
+		r = value
+		return false
+	})
+	return
+}
+
+func (v *View) each(start, end int, name string, fn func(value string) bool) {
+	for i := start; i < end; i++ {
+		if v.lines[i].field != 'a' {
+			continue
+		}
+		a := v.value(i)
+		if !strings.HasPrefix(a, name) {
+			continue
+		}
+		switch {
+		case len(a) == len(name):
+			a = ""
+		case a[len(name)] == ':':
+			a = a[len(name)+1:]
+		default:
+			continue
+		}
+		if !fn(a) {
+			return
+		}
+	}
+}
+
+func (v *View) mode(start, end int) (r string) {
+	for i := start; i < end; i++ {
+		if v.lines[i].field != 'a' {
+			continue
+		}
+		switch a := v.value(i); a {
+		case SendRecv, SendOnly, RecvOnly, Inactive:
+			r = a
+		}
+	}
+	return
+}
+
+// MediaView is a read-only view of a media description.
+type MediaView struct {
+	v          *View
+	start, end int
+}
+
+// Type returns the media type.
+func (m MediaView) Type() string {
+	return m.mediaField(0)
+}
+
+// Port returns the media port.
+func (m MediaView) Port() int {
+	p := m.mediaField(1)
+	if i := strings.IndexByte(p, '/'); i >= 0 {
+		p = p[:i]
+	}
+	v, _ := strconv.Atoi(p)
+	return v
+}
+
+// Proto returns the media transport protocol.
+func (m MediaView) Proto() string {
+	return m.mediaField(2)
+}
+
+// Formats returns the media format list, for example RTP payload types separated by spaces.
+func (m MediaView) Formats() string {
+	return m.mediaField(3)
+}
+
+// Mid returns the media identification ("a=mid").
+func (m MediaView) Mid() string {
+	return m.Get("mid")
+}
+
+// Field returns the value of the first media-level field, for example 'c' for connection data.
+func (m MediaView) Field(f byte) string {
+	for i := m.start + 1; i < m.end; i++ {
+		if m.v.lines[i].field == f {
+			return m.v.value(i)
+		}
+	}
+	return ""
+}
+
+// Has checks presence of the media-level attribute by name.
+func (m MediaView) Has(name string) bool {
+	return m.v.has(m.start, m.end, name)
+}
+
+// Get returns the media-level attribute value by name.
+func (m MediaView) Get(name string) string {
+	return m.v.get(m.start, m.end, name)
+}
+
+// EachAttr calls fn for values of media-level attributes with the name until fn returns false.
+func (m MediaView) EachAttr(name string, fn func(value string) bool) {
+	m.v.each(m.start, m.end, name, fn)
+}
+
+// Mode returns the media-level streaming mode or an empty string if it is not specified.
+func (m MediaView) Mode() string {
+	return m.v.mode(m.start, m.end)
+}
+
+// Media decodes the media description.
+func (m MediaView) Media() (*Media, error) {
+	d, r := new(Decoder), new(Media)
+	for i := m.start; i < m.end; i++ {
+		if err := d.media(r, m.v.lines[i].field, m.v.value(i)); err != nil {
+			return nil, &errDecode{err, i + 1, m.v.s[m.v.lines[i].start-2 : m.v.lines[i].end]}
+		}
+	}
+	return r, nil
+}
+
+// mediaField returns the space-separated field of the "m=" line, the last field includes the rest of the line.
+func (m MediaView) mediaField(n int) string {
+	s := m.v.value(m.start)
+	for k := 0; k < n; k++ {
+		i := strings.IndexByte(s, ' ')
+		if i < 0 {
+			return ""
+		}
+		s = s[i+1:]
+	}
+	if n < 3 {
+		if i := strings.IndexByte(s, ' '); i >= 0 {
+			s = s[:i]
+		}
+	}
+	return s
+}
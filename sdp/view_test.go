@@ -0,0 +1,54 @@
+package sdp
+
+import "testing"
+
+func TestView(t *testing.T) {
+	for _, it := range testVectors {
+		v, err := NewView([]byte(it.Data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.NumMedia() != len(it.Session.Media) || v.Field('s') != it.Session.Name || v.Mode() != it.Session.Mode {
+			t.Errorf("bad view of %s", it.Name)
+		}
+		for i, exp := range it.Session.Media {
+			m := v.Media(i)
+			if m.Type() != exp.Type || m.Port() != exp.Port || m.Proto() != exp.Proto || m.Mode() != exp.Mode {
+				t.Errorf("bad media %d view of %s: %s %d %s", i, it.Name, m.Type(), m.Port(), m.Proto())
+			}
+			if m.Mid() != exp.Attributes.Get("mid") {
+				t.Errorf("bad media %d mid of %s: %s", i, it.Name, m.Mid())
+			}
+			media, err := m.Media()
+			if err != nil {
+				t.Fatal(err)
+			}
+			(&T{t}).AssertAny(it.Name, media, exp)
+		}
+		sess, err := v.Session()
+		if err != nil {
+			t.Fatal(err)
+		}
+		(&T{t}).AssertAny(it.Name, sess, it.Session)
+	}
+	v, err := NewViewString("v=0\r\no=- 1 1 IN IP4 192.0.2.1\r\ns=-\r\nt=0 0\r\na=group:BUNDLE 0 1\r\n" +
+		"m=audio 9/2 UDP/TLS/RTP/SAVPF 111 0\r\na=mid:0\r\na=rtpmap:111 opus/48000/2\r\na=rtpmap:0 PCMU/8000\r\na=rtcp-mux\r\na=rtcp:9\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := v.Media(0)
+	if v.Get("group") != "BUNDLE 0 1" || m.Port() != 9 || m.Formats() != "111 0" || !m.Has("rtcp-mux") || m.Get("rtcp") != "9" {
+		t.Errorf("bad view")
+	}
+	var codecs []string
+	m.EachAttr("rtpmap", func(v string) bool {
+		codecs = append(codecs, v)
+		return true
+	})
+	if len(codecs) != 2 || codecs[1] != "0 PCMU/8000" {
+		t.Errorf("bad codecs: %v", codecs)
+	}
+	if _, err := NewViewString("v=0\r\nbad\r\n"); err == nil {
+		t.Errorf("expected error")
+	}
+}
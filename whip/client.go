@@ -0,0 +1,184 @@
+package whip
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pixelbender/go-sdp/sdp"
+)
+
+// Client is a WHIP or WHEP client.
+type Client struct {
+	Endpoint   string       // Endpoint URL
+	Token      string       // Bearer token for the Authorization header, if any
+	HTTPClient *http.Client // HTTP client or http.DefaultClient if nil
+}
+
+// Session is a client handle of a session resource created by the endpoint.
+type Session struct {
+	Location string       // Resource URL
+	ETag     string       // Entity tag of the resource, if any
+	Answer   *sdp.Session // Remote answer
+
+	c *Client
+}
+
+// Offer sends the local offer to the endpoint and returns the created session resource.
+func (c *Client) Offer(ctx context.Context, offer *sdp.Session) (*Session, error) {
+	b, err := encode(offer)
+	if err != nil {
+		return nil, err
+	}
+	res, body, err := c.do(ctx, http.MethodPost, c.Endpoint, sdp.ContentType, b, "")
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusCreated {
+		return nil, statusError(res, body)
+	}
+	loc := res.Header.Get("Location")
+	if loc == "" {
+		return nil, errLocation
+	}
+	if loc, err = resolve(c.Endpoint, loc); err != nil {
+		return nil, err
+	}
+	answer, err := sdp.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{Location: loc, ETag: res.Header.Get("ETag"), Answer: answer, c: c}, nil
+}
+
+// Trickle sends the fragment with local candidates to the session resource.
+func (s *Session) Trickle(ctx context.Context, frag *sdp.Fragment) error {
+	b, err := encodeFragment(frag)
+	if err != nil {
+		return err
+	}
+	res, body, err := s.c.do(ctx, http.MethodPatch, s.Location, sdp.FragmentContentType, b, s.ETag)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return statusError(res, body)
+	}
+	return nil
+}
+
+// Restart sends the fragment with new local ICE credentials and candidates to the session resource
+// and returns the remote fragment with new remote ICE credentials. The answer is updated accordingly.
+func (s *Session) Restart(ctx context.Context, frag *sdp.Fragment) (*sdp.Fragment, error) {
+	b, err := encodeFragment(frag)
+	if err != nil {
+		return nil, err
+	}
+	res, body, err := s.c.do(ctx, http.MethodPatch, s.Location, sdp.FragmentContentType, b, "*")
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, statusError(res, body)
+	}
+	remote, err := sdp.ParseFragment(body)
+	if err != nil {
+		return nil, err
+	}
+	if err = restart(s.Answer, remote); err != nil {
+		return nil, err
+	}
+	if v := res.Header.Get("ETag"); v != "" {
+		s.ETag = v
+	}
+	return remote, nil
+}
+
+// Close deletes the session resource.
+func (s *Session) Close(ctx context.Context) error {
+	res, body, err := s.c.do(ctx, http.MethodDelete, s.Location, "", nil, "")
+	if err != nil {
+		return err
+	}
+	if res.StatusCode/100 != 2 {
+		return statusError(res, body)
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, target, contentType string, body []byte, match string) (*http.Response, []byte, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if match != "" {
+		req.Header.Set("If-Match", match)
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+	b, err := ioutil.ReadAll(io.LimitReader(res.Body, maxBodySize))
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, b, nil
+}
+
+// encode returns the encoded session description or *sdp.EncodeError if it has invalid fields.
+func encode(s *sdp.Session) ([]byte, error) {
+	e := sdp.NewEncoder(nil)
+	if err := e.Encode(s); err != nil {
+		return nil, err
+	}
+	return e.Bytes(), nil
+}
+
+// encodeFragment returns the encoded SDP fragment or *sdp.EncodeError if it has invalid fields.
+func encodeFragment(f *sdp.Fragment) ([]byte, error) {
+	e := sdp.NewEncoder(nil)
+	if err := e.EncodeFragment(f); err != nil {
+		return nil, err
+	}
+	return e.Bytes(), nil
+}
+
+func statusError(res *http.Response, body []byte) error {
+	e := &StatusError{Code: res.StatusCode}
+	if msg := strings.TrimSpace(string(body)); msg != "" {
+		e.Err = errors.New(msg)
+	}
+	return e
+}
+
+func resolve(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return b.ResolveReference(r).String(), nil
+}
@@ -0,0 +1,253 @@
+package whip
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+	"sync"
+
+	"github.com/pixelbender/go-sdp/sdp"
+)
+
+const maxBodySize = 1 << 20
+
+// Resource represents a WHIP or WHEP session resource created by the handler.
+type Resource struct {
+	ID     string       // Resource ID, the last segment of the resource URL
+	Offer  *sdp.Session // Remote offer updated by trickle ICE and ICE restarts
+	Answer *sdp.Session // Local answer updated by ICE restarts
+
+	mu   sync.Mutex
+	etag string
+}
+
+// Handler serves the WHIP or WHEP endpoint URL and its session resources.
+// Offers are POSTed to the endpoint URL, and resource URLs, the endpoint URL followed by the resource ID,
+// accept PATCH and DELETE requests. Both URLs must be routed to the handler with unmodified paths.
+type Handler struct {
+	// Offer returns the answer for the remote offer. It is required.
+	Offer func(offer *sdp.Session) (*sdp.Session, error)
+	// Trickle is called after candidates of the fragment are merged into the resource offer.
+	// If it is nil, trickle ICE is not supported.
+	Trickle func(r *Resource, frag *sdp.Fragment) error
+	// Restart returns the local fragment with new ICE credentials for the ICE restart fragment.
+	// If it is nil, ICE restarts are not supported.
+	Restart func(r *Resource, frag *sdp.Fragment) (*sdp.Fragment, error)
+	// Delete is called after the resource is deleted.
+	Delete func(r *Resource)
+
+	mu        sync.Mutex
+	resources map[string]*Resource
+}
+
+// NewHandler returns a new handler answering offers with fn.
+func NewHandler(fn func(offer *sdp.Session) (*sdp.Session, error)) *Handler {
+	return &Handler{Offer: fn}
+}
+
+// Resource returns the session resource by ID or nil if not found.
+func (h *Handler) Resource(id string) *Resource {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.resources[id]
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("Accept-Post", sdp.ContentType)
+		if h.Trickle != nil || h.Restart != nil {
+			w.Header().Set("Accept-Patch", sdp.FragmentContentType)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPost:
+		h.post(w, r)
+	case http.MethodPatch:
+		h.patch(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		w.Header().Set("Allow", "OPTIONS, POST, PATCH, DELETE")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) post(w http.ResponseWriter, r *http.Request) {
+	body, ok := readBody(w, r, sdp.ContentType)
+	if !ok {
+		return
+	}
+	offer, err := sdp.Parse(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	answer, err := h.Offer(offer)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	b, err := encode(answer)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	res := &Resource{ID: randomString(), Offer: offer, Answer: answer, etag: etag()}
+	h.mu.Lock()
+	if h.resources == nil {
+		h.resources = make(map[string]*Resource)
+	}
+	h.resources[res.ID] = res
+	h.mu.Unlock()
+
+	hdr := w.Header()
+	hdr.Set("Content-Type", sdp.ContentType)
+	hdr.Set("Location", path.Join(r.URL.Path, res.ID))
+	hdr.Set("ETag", res.etag)
+	if h.Trickle != nil || h.Restart != nil {
+		hdr.Set("Accept-Patch", sdp.FragmentContentType)
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write(b)
+}
+
+func (h *Handler) patch(w http.ResponseWriter, r *http.Request) {
+	if h.Trickle == nil && h.Restart == nil {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	res := h.Resource(path.Base(r.URL.Path))
+	if res == nil {
+		http.NotFound(w, r)
+		return
+	}
+	body, ok := readBody(w, r, sdp.FragmentContentType)
+	if !ok {
+		return
+	}
+	frag, err := sdp.ParseFragment(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	res.mu.Lock()
+	defer res.mu.Unlock()
+
+	match := r.Header.Get("If-Match")
+	if match != "" && match != "*" && match != res.etag {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	ufrag := iceUfrag(frag)
+	if ufrag == "" || ufrag == sessionUfrag(res.Offer) {
+		if h.Trickle == nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		if err = res.Offer.Merge(frag); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if err = h.Trickle(res, frag); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if h.Restart == nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+	if err = checkMedia(res.Offer, frag); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	local, err := h.Restart(res, frag)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	b, err := encodeFragment(local)
+	if err == nil {
+		err = checkMedia(res.Answer, local)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err = restart(res.Offer, frag); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err = restart(res.Answer, local); err != nil {
+		writeError(w, err)
+		return
+	}
+	res.etag = etag()
+	hdr := w.Header()
+	hdr.Set("Content-Type", sdp.FragmentContentType)
+	hdr.Set("ETag", res.etag)
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	id := path.Base(r.URL.Path)
+	h.mu.Lock()
+	res := h.resources[id]
+	delete(h.resources, id)
+	h.mu.Unlock()
+	if res == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if h.Delete != nil {
+		h.Delete(res)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func readBody(w http.ResponseWriter, r *http.Request, contentType string) ([]byte, bool) {
+	typ, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || typ != contentType {
+		w.Header().Set("Accept", contentType)
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return nil, false
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return b, true
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	if e, ok := err.(*StatusError); ok {
+		msg := http.StatusText(e.Code)
+		if e.Err != nil {
+			msg = e.Err.Error()
+		}
+		http.Error(w, msg, e.Code)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func etag() string {
+	return `"` + randomString() + `"`
+}
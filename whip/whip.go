@@ -0,0 +1,96 @@
+// Package whip implements WebRTC-HTTP ingestion (WHIP, RFC 9725) and egress (WHEP) signaling
+// over the SDP session description model.
+//
+// A client POSTs an "application/sdp" offer to the endpoint URL and receives an answer
+// and a session resource URL in the Location header. The resource URL accepts PATCH requests
+// with "application/trickle-ice-sdpfrag" bodies for trickle ICE and ICE restarts,
+// and DELETE requests to tear the session down.
+package whip
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pixelbender/go-sdp/sdp"
+)
+
+// StatusError is an error with HTTP status code.
+// Handler callbacks return it to reply with a status other than 500 Internal Server Error.
+type StatusError struct {
+	Code int
+	Err  error
+}
+
+func (e *StatusError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("whip: status %d", e.Code)
+	}
+	return fmt.Sprintf("whip: status %d: %s", e.Code, e.Err.Error())
+}
+
+// iceUfrag returns the first ICE username fragment of the SDP fragment.
+func iceUfrag(f *sdp.Fragment) string {
+	if v := f.Attributes.Get("ice-ufrag"); v != "" {
+		return v
+	}
+	for _, m := range f.Media {
+		if v := m.Attributes.Get("ice-ufrag"); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// sessionUfrag returns the first ICE username fragment of the session description.
+func sessionUfrag(s *sdp.Session) string {
+	if v := s.Attributes.Get("ice-ufrag"); v != "" {
+		return v
+	}
+	for _, m := range s.Media {
+		if v := m.Attributes.Get("ice-ufrag"); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// checkMedia reports an error if some media description of the fragment is not found in the session.
+func checkMedia(s *sdp.Session, f *sdp.Fragment) error {
+	for i, fm := range f.Media {
+		if s.MediaByMid(fm.Attributes.Get("mid"), i) == nil {
+			return errMedia
+		}
+	}
+	return nil
+}
+
+// restart replaces ICE credentials and candidates of the session with ones from the fragment.
+// The session is not modified if the fragment does not match it.
+func restart(s *sdp.Session, f *sdp.Fragment) error {
+	if err := checkMedia(s, f); err != nil {
+		return err
+	}
+	s.Attributes = sdp.DeleteAttr(s.Attributes, "ice-ufrag", "ice-pwd", "end-of-candidates")
+	for _, m := range s.Media {
+		m.Attributes = sdp.DeleteAttr(m.Attributes, "ice-ufrag", "ice-pwd", "candidate", "end-of-candidates")
+	}
+	for _, a := range f.Attributes {
+		switch a.Name {
+		case "ice-ufrag", "ice-pwd":
+			s.Attributes = append(s.Attributes, sdp.NewAttr(a.Name, a.Value))
+		}
+	}
+	for i, fm := range f.Media {
+		m := s.MediaByMid(fm.Attributes.Get("mid"), i)
+		for _, a := range fm.Attributes {
+			switch a.Name {
+			case "ice-ufrag", "ice-pwd":
+				m.Attributes = append(m.Attributes, sdp.NewAttr(a.Name, a.Value))
+			}
+		}
+	}
+	return s.Merge(f)
+}
+
+var errMedia = errors.New("whip: fragment media description not found")
+var errLocation = errors.New("whip: missing location header")
@@ -0,0 +1,116 @@
+package whip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pixelbender/go-sdp/sdp"
+)
+
+func testSession(ufrag string) *sdp.Session {
+	return &sdp.Session{
+		Origin:     &sdp.Origin{Username: "-", Address: "127.0.0.1"},
+		Name:       "-",
+		Attributes: sdp.Attributes{sdp.NewAttr("ice-ufrag", ufrag), sdp.NewAttr("ice-pwd", ufrag+"-pwd")},
+		Media: []*sdp.Media{{
+			Type:       "audio",
+			Port:       9,
+			Proto:      "UDP/TLS/RTP/SAVPF",
+			Format:     []*sdp.Format{{Payload: 111, Name: "opus", ClockRate: 48000, Channels: 2}},
+			Attributes: sdp.Attributes{sdp.NewAttr("mid", "0")},
+			Mode:       sdp.SendOnly,
+		}},
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var trickled, restarted, deleted int
+	h := NewHandler(func(offer *sdp.Session) (*sdp.Session, error) {
+		if len(offer.Media) == 0 {
+			return nil, &StatusError{Code: http.StatusNotAcceptable}
+		}
+		return testSession("server"), nil
+	})
+	h.Trickle = func(r *Resource, frag *sdp.Fragment) error {
+		trickled++
+		return nil
+	}
+	h.Restart = func(r *Resource, frag *sdp.Fragment) (*sdp.Fragment, error) {
+		restarted++
+		return testSession("server2").Fragment(), nil
+	}
+	h.Delete = func(r *Resource) {
+		deleted++
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/whip", h)
+	mux.Handle("/whip/", h)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := &Client{Endpoint: srv.URL + "/whip", HTTPClient: srv.Client()}
+	if _, err := c.Offer(ctx, &sdp.Session{Origin: &sdp.Origin{}}); err == nil || err.(*StatusError).Code != http.StatusNotAcceptable {
+		t.Fatalf("expected not acceptable error, got: %v", err)
+	}
+	invalid := testSession("client")
+	invalid.Name = "x\r\nm=video"
+	if _, err := c.Offer(ctx, invalid); err == nil || err.Error() != "sdp: invalid character in s=" {
+		t.Fatalf("expected encode error, got: %v", err)
+	}
+	sess, err := c.Offer(ctx, testSession("client"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sess.Location, srv.URL+"/whip/") || sess.ETag == "" {
+		t.Fatalf("bad session resource: %+v", sess)
+	}
+	if v := sess.Answer.Attributes.Get("ice-ufrag"); v != "server" {
+		t.Fatalf("bad answer ufrag: %s", v)
+	}
+	id := strings.TrimPrefix(sess.Location, srv.URL+"/whip/")
+
+	frag := testSession("client").Fragment()
+	frag.Media[0].Attributes = append(frag.Media[0].Attributes, sdp.NewAttr("candidate", "1 1 UDP 2130706431 192.0.2.1 5000 typ host"))
+	if err = sess.Trickle(ctx, frag); err != nil {
+		t.Fatal(err)
+	}
+	if trickled != 1 || !h.Resource(id).Offer.Media[0].Attributes.Has("candidate") {
+		t.Fatal("candidate is not trickled")
+	}
+	etag := sess.ETag
+	frag = testSession("client2").Fragment()
+	frag.Media[0].Attributes = sdp.Attributes{sdp.NewAttr("mid", "unknown")}
+	if _, err = sess.Restart(ctx, frag); err == nil || err.(*StatusError).Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected unprocessable entity error, got: %v", err)
+	}
+	if restarted != 0 || h.Resource(id).Offer.Attributes.Get("ice-ufrag") != "client" {
+		t.Fatal("restart is applied for unknown media")
+	}
+	remote, err := sess.Restart(ctx, testSession("client2").Fragment())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remote.Attributes.Get("ice-ufrag") != "server2" || sess.Answer.Attributes.Get("ice-ufrag") != "server2" || sess.ETag == etag {
+		t.Fatalf("bad restart: %s", remote)
+	}
+	if res := h.Resource(id); res.Offer.Attributes.Get("ice-ufrag") != "client2" || res.Offer.Media[0].Attributes.Has("candidate") {
+		t.Fatalf("bad restarted offer: %s", res.Offer)
+	}
+	sess.ETag = etag
+	if err = sess.Trickle(ctx, testSession("client2").Fragment()); err == nil || err.(*StatusError).Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected precondition failed error, got: %v", err)
+	}
+	if err = sess.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 1 || h.Resource(id) != nil {
+		t.Fatal("resource is not deleted")
+	}
+	if err = sess.Close(ctx); err == nil {
+		t.Fatal("expected not found error")
+	}
+}